@@ -0,0 +1,72 @@
+// Command leaderboard-rebuild reloads the redis leaderboard board from the
+// users_scoreboard table, e.g. after Redis lost its data
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	_ "github.com/lib/pq"
+	goredis "github.com/redis/go-redis/v9"
+	redisboard "github.com/vo1dFl0w/users-service/internal/app/adapters/board/redis"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/storage/postgres"
+	"github.com/vo1dFl0w/users-service/internal/app/config"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/leaderboard_usecase"
+)
+
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	if err := run(ctx); err != nil {
+		log.Println("leaderboard rebuild failed:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	databaseDSN := fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		cfg.DB.Host,
+		cfg.DB.Port,
+		cfg.DB.Username,
+		cfg.DB.Password,
+		cfg.DB.DBname,
+		cfg.DB.Sslmode,
+	)
+
+	db, err := sql.Open("postgres", databaseDSN)
+	if err != nil {
+		return fmt.Errorf("failed to launch database: %w", err)
+	}
+	defer db.Close()
+
+	client := goredis.NewClient(&goredis.Options{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	defer client.Close()
+
+	board := redisboard.New(client, cfg.Leaderboard.RedisKey)
+
+	leaderboardService := leaderboard_usecase.NewService(postgres.New(db).Leaderboard(), board, time.UTC)
+
+	start := time.Now()
+	if err := leaderboardService.Sync(ctx); err != nil {
+		return err
+	}
+
+	log.Printf("leaderboard %q rebuilt in %s", cfg.Leaderboard.RedisKey, time.Since(start).Round(time.Millisecond))
+
+	return nil
+}
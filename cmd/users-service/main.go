@@ -5,20 +5,46 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	_ "github.com/lib/pq"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/board/memory"
+	redisboard "github.com/vo1dFl0w/users-service/internal/app/adapters/board/redis"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/events"
 	http_adaptor "github.com/vo1dFl0w/users-service/internal/app/adapters/http"
 	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/jwt"
 	"github.com/vo1dFl0w/users-service/internal/app/adapters/storage/postgres"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/telegram"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/verifier"
 	"github.com/vo1dFl0w/users-service/internal/app/config"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/level_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
 	"github.com/vo1dFl0w/users-service/internal/app/logger"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/achievement_usecase"
 	"github.com/vo1dFl0w/users-service/internal/app/usecase/auth_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/challenge_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/leaderboard_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/level_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/notification_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/quest_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/score_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/season_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/store_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/streak_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/task_usecase"
 	"github.com/vo1dFl0w/users-service/internal/app/usecase/user_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/verification_usecase"
 )
 
 func main() {
@@ -61,15 +87,147 @@ func run(ctx context.Context) error {
 
 	tokenService := jwt.New([]byte(cfg.Secret))
 
+	bus := events.New(log)
+
+	levels, err := level_domain.NewCurve(cfg.Levels.Curve, cfg.Levels.Step, cfg.Levels.Factor, cfg.Levels.Table)
+	if err != nil {
+		return fmt.Errorf("failed to load level curve: %w", err)
+	}
+
+	streaks := streak_domain.Rules{
+		FreezeEvery: cfg.Streaks.FreezeEvery,
+		MaxFreezes:  cfg.Streaks.MaxFreezes,
+		Milestones:  cfg.Streaks.Milestones,
+	}
+
+	leaderboardLocation, err := time.LoadLocation(cfg.Leaderboard.Timezone)
+	if err != nil {
+		return fmt.Errorf("failed to load leaderboard timezone: %w", err)
+	}
+
 	authRepository := store.Auth()
 	authService := auth_usecase.NewService(authRepository, tokenService)
 
+	verifiers := map[string]verification_domain.Verifier{
+		verification_domain.VerifierManual:   verifier.NewManual(),
+		verification_domain.VerifierCallback: verifier.NewCallback(),
+	}
+	if cfg.Env == "local" {
+		verifiers[verification_domain.VerifierFake] = verifier.NewFake()
+	}
+
+	verificationRepository := store.Verification()
+
 	userRepository := store.User()
-	userService := user_usecase.NewService(userRepository)
+
+	// linking stays disabled without a bot token, there is nothing to check the login with
+	var telegramLogin user_domain.TelegramLogin
+	if cfg.Telegram.BotToken != "" {
+		telegramLogin = telegram.NewLogin(cfg.Telegram.BotToken, cfg.Telegram.LoginMaxAge)
+
+		telegramClient := telegram.New(
+			cfg.Telegram.BaseURL,
+			cfg.Telegram.BotToken,
+			cfg.Telegram.ChatID,
+			cfg.Telegram.CacheTTL,
+			cfg.Telegram.Timeout,
+			userRepository,
+		)
+		verifiers[verification_domain.VerifierTelegram] = verifier.NewMembership(telegramClient)
+	}
+
+	userService := user_usecase.NewService(userRepository, verificationRepository, verifiers, bus, levels, streaks, telegramLogin)
+
+	verificationService := verification_usecase.NewService(verificationRepository, userRepository, []byte(cfg.Verification.CallbackSecret), cfg.Verification.CallbackMaxAge, bus, streaks)
+
+	taskRepository := store.Task()
+	taskService := task_usecase.NewService(taskRepository)
+
+	questRepository := store.Quest()
+	questService := quest_usecase.NewService(questRepository)
+
+	scoreRepository := store.Score()
+	scoreService := score_usecase.NewService(scoreRepository, cfg.Transfers, bus)
+
+	storeRepository := store.Store()
+	storeService := store_usecase.NewService(storeRepository)
+
+	streakRepository := store.Streak()
+	streakService := streak_usecase.NewService(streakRepository)
+
+	achievementRepository := store.Achievement()
+	achievementService := achievement_usecase.NewService(achievementRepository, bus)
+
+	levelService := level_usecase.NewService(levels, bus)
+
+	bus.Subscribe(event_domain.TypeScoreChanged, levelService.HandleEvent)
+
+	bus.Subscribe(event_domain.TypeScoreChanged, achievementService.HandleEvent)
+	bus.Subscribe(event_domain.TypeTaskCompleted, achievementService.HandleEvent)
+	bus.Subscribe(event_domain.TypeReferrerSet, achievementService.HandleEvent)
+
+	var board leaderboard_domain.Board
+	switch cfg.Leaderboard.Board {
+	case leaderboard_domain.BoardPostgres:
+	case leaderboard_domain.BoardMemory:
+		board = memory.New()
+	case leaderboard_domain.BoardRedis:
+		client := goredis.NewClient(&goredis.Options{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		defer client.Close()
+
+		board = redisboard.New(client, cfg.Leaderboard.RedisKey)
+	default:
+		return fmt.Errorf("unknown leaderboard board %q", cfg.Leaderboard.Board)
+	}
+
+	leaderboardRepository := store.Leaderboard()
+	leaderboardService := leaderboard_usecase.NewService(leaderboardRepository, board, leaderboardLocation)
+
+	// the redis board is shared, replacing it with one instance's snapshot
+	// loses or doubles the increments other instances make meanwhile, so
+	// only cmd/leaderboard-rebuild rebuilds it
+	syncBoard := board != nil && cfg.Leaderboard.Board != leaderboard_domain.BoardRedis
+	if syncBoard {
+		if err := leaderboardService.Sync(ctx); err != nil {
+			return fmt.Errorf("failed to load leaderboard: %w", err)
+		}
+	}
+
+	bus.Subscribe(event_domain.TypeScoreChanged, leaderboardService.HandleEvent)
+
+	// the feed reads the board, so it follows the board update above
+	leaderboardFeed := leaderboard_usecase.NewFeed(leaderboardService, cfg.Leaderboard.StreamTop, cfg.Leaderboard.StreamInterval, cfg.Leaderboard.StreamHeartbeat, log)
+	bus.Subscribe(event_domain.TypeScoreChanged, leaderboardFeed.HandleEvent)
+
+	// rank changes reach live connections through the feed
+	notificationHub := notification_usecase.NewHub(leaderboardFeed, cfg.Notifications.History, cfg.Notifications.Queue, cfg.Notifications.PingInterval, log)
+	bus.Subscribe(event_domain.TypeTaskReviewed, notificationHub.HandleEvent)
+	bus.Subscribe(event_domain.TypeReferralCredited, notificationHub.HandleEvent)
+	bus.Subscribe(event_domain.TypeAchievementUnlocked, notificationHub.HandleEvent)
+
+	seasonRepository := store.Season()
+	seasonService := season_usecase.NewService(seasonRepository, leaderboardRepository, bus)
+
+	challengeService := challenge_usecase.NewService([]byte(cfg.Secret), cfg.Challenge)
+
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+
+	go runCampaigns(jobsCtx, log, taskService, cfg.Campaigns.Interval)
+	go runSeasons(jobsCtx, log, seasonService, cfg.Seasons.Interval)
+	go leaderboardFeed.Run(jobsCtx)
+	go reconcileAchievements(jobsCtx, log, achievementService)
+	if syncBoard {
+		go runLeaderboardSync(jobsCtx, log, leaderboardService, cfg.Leaderboard.SyncInterval)
+	}
 
 	server := &http.Server{
 		Addr:    cfg.HTTPaddr,
-		Handler: http_adaptor.NewHandler(log, tokenService, authService, userService),
+		Handler: http_adaptor.NewHandler(log, tokenService, authService, userService, challengeService, taskService, verificationService, questService, scoreService, storeService, achievementService, streakService, leaderboardService, leaderboardFeed, seasonService, notificationHub),
 	}
 
 	shutdown := make(chan os.Signal, 1)
@@ -101,3 +259,78 @@ func run(ctx context.Context) error {
 		return nil
 	}
 }
+
+// runCampaigns launches and retires time-boxed tasks until ctx is cancelled
+func runCampaigns(ctx context.Context, log *slog.Logger, taskService task_usecase.Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		launched, retired, err := taskService.SyncCampaigns(ctx)
+		if err != nil {
+			log.Error("failed to sync campaigns", "err", err)
+		} else if launched > 0 || retired > 0 {
+			log.Info("campaigns synced", "launched", launched, "retired", retired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runSeasons closes ended seasons until ctx is cancelled
+func runSeasons(ctx context.Context, log *slog.Logger, seasonService season_usecase.Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		closed, err := seasonService.CloseSeasons(ctx)
+		if err != nil {
+			log.Error("failed to close seasons", "err", err)
+		}
+		if closed > 0 {
+			log.Info("seasons closed", "closed", closed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcileAchievements unlocks at startup what evaluations failed to
+// unlock before
+func reconcileAchievements(ctx context.Context, log *slog.Logger, achievementService achievement_usecase.Service) {
+	unlocked, err := achievementService.Reconcile(ctx)
+	if err != nil {
+		log.Error("failed to reconcile achievements", "err", err)
+		return
+	}
+	if unlocked > 0 {
+		log.Info("achievements reconciled", "unlocked", unlocked)
+	}
+}
+
+// runLeaderboardSync reloads the leaderboard board until ctx is cancelled,
+// which repairs changes that reached the board out of step with the database
+func runLeaderboardSync(ctx context.Context, log *slog.Logger, leaderboardService leaderboard_usecase.Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := leaderboardService.Sync(ctx); err != nil {
+			log.Error("failed to sync leaderboard", "err", err)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+)
+
+// Board is an in-process leaderboard_domain.Board, users are kept in a skip
+// list ordered like the users_scoreboard rank index
+type Board struct {
+	mu     sync.RWMutex
+	list   *skipList
+	scores map[uuid.UUID]int64
+}
+
+func New() *Board {
+	return &Board{
+		list:   newSkipList(),
+		scores: make(map[uuid.UUID]int64),
+	}
+}
+
+func (b *Board) Load(ctx context.Context, entries []*leaderboard_domain.Entry) error {
+	list := newSkipList()
+	scores := make(map[uuid.UUID]int64, len(entries))
+
+	for _, e := range entries {
+		if _, ok := scores[e.UserID]; ok {
+			continue
+		}
+		scores[e.UserID] = e.Score
+		list.insert(key{score: e.Score, userID: e.UserID})
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.list = list
+	b.scores = scores
+
+	return nil
+}
+
+func (b *Board) Add(ctx context.Context, userID uuid.UUID, delta int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	score, ok := b.scores[userID]
+	if ok {
+		b.list.delete(key{score: score, userID: userID})
+	}
+
+	score += delta
+	b.scores[userID] = score
+	b.list.insert(key{score: score, userID: userID})
+
+	return nil
+}
+
+func (b *Board) Top(ctx context.Context, after *leaderboard_domain.Cursor, limit int) ([]*leaderboard_domain.Entry, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	rank, x := 0, b.list.head.next[0]
+	if after != nil {
+		rank, x = b.list.upTo(key{score: after.Score, userID: after.UserID})
+	}
+
+	return collect(x, rank+1, limit), nil
+}
+
+func (b *Board) Rank(ctx context.Context, userID uuid.UUID) (*leaderboard_domain.Standing, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	score, ok := b.scores[userID]
+	if !ok {
+		return nil, leaderboard_domain.ErrUserNotFound
+	}
+
+	rank, _ := b.list.upTo(key{score: score, userID: userID})
+
+	st := &leaderboard_domain.Standing{Total: int64(b.list.length)}
+	st.Rank = int64(rank)
+	st.UserID = userID
+	st.Score = score
+
+	return st, nil
+}
+
+func (b *Board) Ranks(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*leaderboard_domain.Standing, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	standings := make(map[uuid.UUID]*leaderboard_domain.Standing, len(userIDs))
+	for _, userID := range userIDs {
+		score, ok := b.scores[userID]
+		if !ok {
+			continue
+		}
+
+		rank, _ := b.list.upTo(key{score: score, userID: userID})
+
+		st := &leaderboard_domain.Standing{Total: int64(b.list.length)}
+		st.Rank = int64(rank)
+		st.UserID = userID
+		st.Score = score
+		standings[userID] = st
+	}
+
+	return standings, nil
+}
+
+func (b *Board) Around(ctx context.Context, userID uuid.UUID, window int) ([]*leaderboard_domain.Entry, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	score, ok := b.scores[userID]
+	if !ok {
+		return nil, leaderboard_domain.ErrUserNotFound
+	}
+
+	rank, _ := b.list.upTo(key{score: score, userID: userID})
+
+	first := max(rank-window, 1)
+
+	return collect(b.list.byRank(first), first, rank+window-first+1), nil
+}
+
+// collect returns up to limit entries starting at x, which has the given rank
+func collect(x *node, rank int, limit int) []*leaderboard_domain.Entry {
+	entries := []*leaderboard_domain.Entry{}
+	for ; x != nil && len(entries) < limit; x = x.next[0] {
+		entries = append(entries, &leaderboard_domain.Entry{
+			Rank:   int64(rank),
+			UserID: x.userID,
+			Score:  x.score,
+		})
+		rank++
+	}
+
+	return entries
+}
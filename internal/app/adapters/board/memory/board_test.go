@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+)
+
+var (
+	userA = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	userB = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	userC = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	userD = uuid.MustParse("00000000-0000-0000-0000-00000000000d")
+	userE = uuid.MustParse("00000000-0000-0000-0000-00000000000e")
+)
+
+func entries(t *testing.T, got []*leaderboard_domain.Entry, want ...leaderboard_domain.Entry) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if *got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, *got[i], want[i])
+		}
+	}
+}
+
+func TestBoard(t *testing.T) {
+	ctx := context.Background()
+	b := New()
+
+	if err := b.Load(ctx, []*leaderboard_domain.Entry{
+		{UserID: userA, Score: 50},
+		{UserID: userB, Score: 100},
+		{UserID: userC, Score: 50},
+		{UserID: userD, Score: 10},
+		// a repeated user keeps the first score
+		{UserID: userA, Score: 999},
+	}); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	t.Run("top", func(t *testing.T) {
+		first, err := b.Top(ctx, nil, 2)
+		if err != nil {
+			t.Fatalf("Top() error = %v", err)
+		}
+		entries(t, first,
+			leaderboard_domain.Entry{Rank: 1, UserID: userB, Score: 100},
+			leaderboard_domain.Entry{Rank: 2, UserID: userA, Score: 50},
+		)
+
+		next, err := b.Top(ctx, first[1].Cursor(), 10)
+		if err != nil {
+			t.Fatalf("Top() error = %v", err)
+		}
+		entries(t, next,
+			leaderboard_domain.Entry{Rank: 3, UserID: userC, Score: 50},
+			leaderboard_domain.Entry{Rank: 4, UserID: userD, Score: 10},
+		)
+
+		last, err := b.Top(ctx, next[1].Cursor(), 10)
+		if err != nil {
+			t.Fatalf("Top() error = %v", err)
+		}
+		entries(t, last)
+
+		// the cursor user has moved since, the page still starts after its old place
+		moved, err := b.Top(ctx, &leaderboard_domain.Cursor{Score: 50, UserID: userB}, 1)
+		if err != nil {
+			t.Fatalf("Top() error = %v", err)
+		}
+		entries(t, moved, leaderboard_domain.Entry{Rank: 3, UserID: userC, Score: 50})
+	})
+
+	if err := b.Add(ctx, userD, 60); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := b.Add(ctx, userE, 5); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	t.Run("add", func(t *testing.T) {
+		top, err := b.Top(ctx, nil, 10)
+		if err != nil {
+			t.Fatalf("Top() error = %v", err)
+		}
+		entries(t, top,
+			leaderboard_domain.Entry{Rank: 1, UserID: userB, Score: 100},
+			leaderboard_domain.Entry{Rank: 2, UserID: userD, Score: 70},
+			leaderboard_domain.Entry{Rank: 3, UserID: userA, Score: 50},
+			leaderboard_domain.Entry{Rank: 4, UserID: userC, Score: 50},
+			leaderboard_domain.Entry{Rank: 5, UserID: userE, Score: 5},
+		)
+	})
+
+	t.Run("rank", func(t *testing.T) {
+		st, err := b.Rank(ctx, userA)
+		if err != nil {
+			t.Fatalf("Rank() error = %v", err)
+		}
+		if st.Rank != 3 || st.Score != 50 || st.Total != 5 || st.UserID != userA {
+			t.Errorf("Rank() = %+v, want rank 3 of 5 with 50", st)
+		}
+
+		if _, err := b.Rank(ctx, uuid.New()); !errors.Is(err, leaderboard_domain.ErrUserNotFound) {
+			t.Errorf("Rank() error = %v, want %v", err, leaderboard_domain.ErrUserNotFound)
+		}
+	})
+
+	t.Run("ranks", func(t *testing.T) {
+		ranks, err := b.Ranks(ctx, []uuid.UUID{userA, uuid.New(), userE})
+		if err != nil {
+			t.Fatalf("Ranks() error = %v", err)
+		}
+		if len(ranks) != 2 {
+			t.Fatalf("Ranks() returned %d users, want 2", len(ranks))
+		}
+		if st := ranks[userA]; st == nil || st.Rank != 3 || st.Score != 50 || st.Total != 5 {
+			t.Errorf("Ranks()[a] = %+v, want rank 3 of 5 with 50", st)
+		}
+		if st := ranks[userE]; st == nil || st.Rank != 5 || st.Score != 5 || st.Total != 5 {
+			t.Errorf("Ranks()[e] = %+v, want rank 5 of 5 with 5", st)
+		}
+	})
+
+	t.Run("around", func(t *testing.T) {
+		around, err := b.Around(ctx, userA, 1)
+		if err != nil {
+			t.Fatalf("Around() error = %v", err)
+		}
+		entries(t, around,
+			leaderboard_domain.Entry{Rank: 2, UserID: userD, Score: 70},
+			leaderboard_domain.Entry{Rank: 3, UserID: userA, Score: 50},
+			leaderboard_domain.Entry{Rank: 4, UserID: userC, Score: 50},
+		)
+
+		around, err = b.Around(ctx, userB, 1)
+		if err != nil {
+			t.Fatalf("Around() error = %v", err)
+		}
+		entries(t, around,
+			leaderboard_domain.Entry{Rank: 1, UserID: userB, Score: 100},
+			leaderboard_domain.Entry{Rank: 2, UserID: userD, Score: 70},
+		)
+
+		if _, err := b.Around(ctx, uuid.New(), 1); !errors.Is(err, leaderboard_domain.ErrUserNotFound) {
+			t.Errorf("Around() error = %v, want %v", err, leaderboard_domain.ErrUserNotFound)
+		}
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		if err := b.Load(ctx, []*leaderboard_domain.Entry{{UserID: userC, Score: 1}}); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		top, err := b.Top(ctx, nil, 10)
+		if err != nil {
+			t.Fatalf("Top() error = %v", err)
+		}
+		entries(t, top, leaderboard_domain.Entry{Rank: 1, UserID: userC, Score: 1})
+
+		if err := b.Load(ctx, nil); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		top, err = b.Top(ctx, nil, 10)
+		if err != nil {
+			t.Fatalf("Top() error = %v", err)
+		}
+		entries(t, top)
+	})
+}
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"bytes"
+	"math/rand/v2"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxLevel = 32
+	// chance of a node to reach every next level
+	levelP = 0.25
+)
+
+// key orders the list like the leaderboard, by score descending and equal
+// scores by user_id
+type key struct {
+	score  int64
+	userID uuid.UUID
+}
+
+func (k key) before(o key) bool {
+	if k.score != o.score {
+		return k.score > o.score
+	}
+
+	return bytes.Compare(k.userID[:], o.userID[:]) < 0
+}
+
+// node keeps for every level the number of level 0 steps its link skips,
+// which lets the list count ranks on the way down
+type node struct {
+	key
+	next []*node
+	span []int
+}
+
+// skipList is an indexable skip list, every operation is O(log n) on average
+type skipList struct {
+	head   *node
+	level  int
+	length int
+}
+
+func newSkipList() *skipList {
+	return &skipList{
+		head:  &node{next: make([]*node, maxLevel), span: make([]int, maxLevel)},
+		level: 1,
+	}
+}
+
+func randomLevel() int {
+	level := 1
+	for level < maxLevel && rand.Float64() < levelP {
+		level++
+	}
+
+	return level
+}
+
+func (l *skipList) insert(k key) {
+	var (
+		update [maxLevel]*node
+		rank   [maxLevel]int
+	)
+
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		if i < l.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i] != nil && x.next[i].before(k) {
+			rank[i] += x.span[i]
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+
+	level := randomLevel()
+	if level > l.level {
+		for i := l.level; i < level; i++ {
+			update[i] = l.head
+			update[i].span[i] = l.length
+		}
+		l.level = level
+	}
+
+	x = &node{key: k, next: make([]*node, level), span: make([]int, level)}
+	for i := 0; i < level; i++ {
+		x.next[i] = update[i].next[i]
+		update[i].next[i] = x
+
+		x.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+
+	for i := level; i < l.level; i++ {
+		update[i].span[i]++
+	}
+
+	l.length++
+}
+
+func (l *skipList) delete(k key) bool {
+	var update [maxLevel]*node
+
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].before(k) {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+
+	x = x.next[0]
+	if x == nil || x.key != k {
+		return false
+	}
+
+	for i := 0; i < l.level; i++ {
+		if update[i].next[i] == x {
+			update[i].span[i] += x.span[i] - 1
+			update[i].next[i] = x.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+
+	for l.level > 1 && l.head.next[l.level-1] == nil {
+		l.level--
+	}
+	l.length--
+
+	return true
+}
+
+// upTo returns the number of nodes ranked at or before k and the first node
+// ranked after it
+func (l *skipList) upTo(k key) (int, *node) {
+	rank := 0
+
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && !k.before(x.next[i].key) {
+			rank += x.span[i]
+			x = x.next[i]
+		}
+	}
+
+	return rank, x.next[0]
+}
+
+// byRank returns the node at the 1-based rank, nil when it is out of range
+func (l *skipList) byRank(rank int) *node {
+	if rank < 1 || rank > l.length {
+		return nil
+	}
+
+	traversed := 0
+
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && traversed+x.span[i] <= rank {
+			traversed += x.span[i]
+			x = x.next[i]
+		}
+		if traversed == rank {
+			return x
+		}
+	}
+
+	return nil
+}
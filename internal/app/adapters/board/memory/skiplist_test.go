@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// check compares the list with the keys it should hold in rank order and
+// walks every level to make sure the spans add up
+func check(t *testing.T, l *skipList, want []key) {
+	t.Helper()
+
+	if l.length != len(want) {
+		t.Fatalf("length = %d, want %d", l.length, len(want))
+	}
+
+	for i, k := range want {
+		rank, next := l.upTo(k)
+		if rank != i+1 {
+			t.Fatalf("upTo(%v) rank = %d, want %d", k, rank, i+1)
+		}
+		if i+1 < len(want) && (next == nil || next.key != want[i+1]) {
+			t.Fatalf("upTo(%v) next = %v, want %v", k, next, want[i+1])
+		}
+		if i+1 == len(want) && next != nil {
+			t.Fatalf("upTo(%v) next = %v, want nil", k, next.key)
+		}
+
+		if x := l.byRank(i + 1); x == nil || x.key != k {
+			t.Fatalf("byRank(%d) = %v, want %v", i+1, x, k)
+		}
+	}
+
+	if l.byRank(0) != nil || l.byRank(len(want)+1) != nil {
+		t.Fatalf("byRank out of range is not nil")
+	}
+
+	ranks := make(map[key]int, len(want))
+	for i, k := range want {
+		ranks[k] = i + 1
+	}
+
+	for i := 0; i < l.level; i++ {
+		rank := 0
+		for x := l.head; x.next[i] != nil; x = x.next[i] {
+			rank += x.span[i]
+			if rank != ranks[x.next[i].key] {
+				t.Fatalf("level %d reaches %v at rank %d, want %d", i, x.next[i].key, rank, ranks[x.next[i].key])
+			}
+		}
+	}
+}
+
+func sorted(keys map[uuid.UUID]int64) []key {
+	want := make([]key, 0, len(keys))
+	for userID, score := range keys {
+		want = append(want, key{score: score, userID: userID})
+	}
+	sort.Slice(want, func(i, j int) bool {
+		return want[i].before(want[j])
+	})
+
+	return want
+}
+
+func TestSkipList(t *testing.T) {
+	l := newSkipList()
+	check(t, l, nil)
+
+	scores := make(map[uuid.UUID]int64)
+	users := make([]uuid.UUID, 200)
+	for i := range users {
+		users[i] = uuid.New()
+	}
+
+	// few distinct scores, so that ties are ordered by user_id
+	for i := 0; i < 2000; i++ {
+		userID := users[rand.IntN(len(users))]
+
+		if score, ok := scores[userID]; ok {
+			if !l.delete(key{score: score, userID: userID}) {
+				t.Fatalf("delete of a present key failed")
+			}
+			delete(scores, userID)
+		}
+
+		if rand.IntN(4) > 0 {
+			score := rand.Int64N(20) - 5
+			l.insert(key{score: score, userID: userID})
+			scores[userID] = score
+		}
+
+		if i%100 == 0 {
+			check(t, l, sorted(scores))
+		}
+	}
+
+	check(t, l, sorted(scores))
+
+	if l.delete(key{score: 1000, userID: uuid.New()}) {
+		t.Errorf("delete of a missing key succeeded")
+	}
+}
+
+func TestKeyOrder(t *testing.T) {
+	a := key{score: 10, userID: uuid.MustParse("00000000-0000-0000-0000-000000000002")}
+	b := key{score: 10, userID: uuid.MustParse("00000000-0000-0000-0000-000000000001")}
+	c := key{score: 20, userID: uuid.MustParse("00000000-0000-0000-0000-000000000003")}
+
+	if !c.before(a) || a.before(c) {
+		t.Errorf("a higher score is not ranked first")
+	}
+	if !b.before(a) || a.before(b) {
+		t.Errorf("an equal score is not ranked by user_id")
+	}
+	if a.before(a) {
+		t.Errorf("a key is ranked before itself")
+	}
+}
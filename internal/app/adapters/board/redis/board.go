@@ -0,0 +1,249 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+)
+
+// loadBatch is the number of members written per ZADD while loading
+const loadBatch = 1000
+
+// Board is a leaderboard_domain.Board on a Redis sorted set shared by every
+// instance of the service. Scores are stored negated: a sorted set orders
+// equal scores by member ascending in ZRANGE and descending in ZREVRANGE, so
+// ascending negated scores keep the (score DESC, user_id) order of Postgres
+type Board struct {
+	client *goredis.Client
+	key    string
+}
+
+func New(client *goredis.Client, key string) *Board {
+	return &Board{
+		client: client,
+		key:    key,
+	}
+}
+
+// afterScript finds where the page after a cursor starts, ties of the cursor score
+// are searched by member since the cursor user may have moved since
+var afterScript = goredis.NewScript(`
+local lo = redis.call('ZCOUNT', KEYS[1], '-inf', '(' .. ARGV[1])
+local hi = redis.call('ZCOUNT', KEYS[1], '-inf', ARGV[1])
+while lo < hi do
+	local mid = math.floor((lo + hi) / 2)
+	if redis.call('ZRANGE', KEYS[1], mid, mid)[1] <= ARGV[2] then
+		lo = mid + 1
+	else
+		hi = mid
+	end
+end
+return {lo, redis.call('ZRANGE', KEYS[1], lo, lo + tonumber(ARGV[3]) - 1, 'WITHSCORES')}
+`)
+
+var aroundScript = goredis.NewScript(`
+local rank = redis.call('ZRANK', KEYS[1], ARGV[1])
+if not rank then
+	return false
+end
+local from = math.max(rank - tonumber(ARGV[2]), 0)
+return {from, redis.call('ZRANGE', KEYS[1], from, rank + tonumber(ARGV[2]), 'WITHSCORES')}
+`)
+
+// Load writes the entries to a temporary key and renames it over the board,
+// readers never see a half loaded board
+func (b *Board) Load(ctx context.Context, entries []*leaderboard_domain.Entry) error {
+	if len(entries) == 0 {
+		if err := b.client.Del(ctx, b.key).Err(); err != nil {
+			return fmt.Errorf("failed to clear leaderboard: %w", err)
+		}
+		return nil
+	}
+
+	tmp := b.key + ":load:" + uuid.NewString()
+
+	for start := 0; start < len(entries); start += loadBatch {
+		batch := entries[start:min(start+loadBatch, len(entries))]
+
+		members := make([]goredis.Z, 0, len(batch))
+		for _, e := range batch {
+			members = append(members, goredis.Z{Score: float64(-e.Score), Member: e.UserID.String()})
+		}
+
+		if err := b.client.ZAdd(ctx, tmp, members...).Err(); err != nil {
+			b.client.Del(ctx, tmp)
+			return fmt.Errorf("failed to load leaderboard: %w", err)
+		}
+	}
+
+	if err := b.client.Rename(ctx, tmp, b.key).Err(); err != nil {
+		b.client.Del(ctx, tmp)
+		return fmt.Errorf("failed to replace leaderboard: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Board) Add(ctx context.Context, userID uuid.UUID, delta int64) error {
+	if err := b.client.ZIncrBy(ctx, b.key, float64(-delta), userID.String()).Err(); err != nil {
+		return fmt.Errorf("failed to update leaderboard: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Board) Top(ctx context.Context, cursor *leaderboard_domain.Cursor, limit int) ([]*leaderboard_domain.Entry, error) {
+	if cursor == nil {
+		members, err := b.client.ZRangeWithScores(ctx, b.key, 0, int64(limit-1)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+		}
+
+		entries := make([]*leaderboard_domain.Entry, 0, len(members))
+		for i, m := range members {
+			e, err := entry(int64(i+1), m.Member, m.Score)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, e)
+		}
+
+		return entries, nil
+	}
+
+	res, err := afterScript.Run(ctx, b.client, []string{b.key},
+		strconv.FormatInt(-cursor.Score, 10), cursor.UserID.String(), limit,
+	).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	return page(res)
+}
+
+func (b *Board) Rank(ctx context.Context, userID uuid.UUID) (*leaderboard_domain.Standing, error) {
+	var (
+		rank  *goredis.IntCmd
+		score *goredis.FloatCmd
+		total *goredis.IntCmd
+	)
+
+	if _, err := b.client.TxPipelined(ctx, func(p goredis.Pipeliner) error {
+		rank = p.ZRank(ctx, b.key, userID.String())
+		score = p.ZScore(ctx, b.key, userID.String())
+		total = p.ZCard(ctx, b.key)
+		return nil
+	}); err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, leaderboard_domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get rank: %w", err)
+	}
+
+	st := &leaderboard_domain.Standing{Total: total.Val()}
+	st.Rank = rank.Val() + 1
+	st.UserID = userID
+	st.Score = int64(-score.Val())
+
+	return st, nil
+}
+
+// Ranks reads every user's rank and score in one pipeline
+func (b *Board) Ranks(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*leaderboard_domain.Standing, error) {
+	var (
+		ranks  = make([]*goredis.IntCmd, len(userIDs))
+		scores = make([]*goredis.FloatCmd, len(userIDs))
+		total  *goredis.IntCmd
+	)
+
+	// a user missing from the board fails its own commands with goredis.Nil
+	if _, err := b.client.TxPipelined(ctx, func(p goredis.Pipeliner) error {
+		for i, userID := range userIDs {
+			ranks[i] = p.ZRank(ctx, b.key, userID.String())
+			scores[i] = p.ZScore(ctx, b.key, userID.String())
+		}
+		total = p.ZCard(ctx, b.key)
+		return nil
+	}); err != nil && !errors.Is(err, goredis.Nil) {
+		return nil, fmt.Errorf("failed to get ranks: %w", err)
+	}
+
+	standings := make(map[uuid.UUID]*leaderboard_domain.Standing, len(userIDs))
+	for i, userID := range userIDs {
+		if err := errors.Join(ranks[i].Err(), scores[i].Err()); err != nil {
+			if errors.Is(err, goredis.Nil) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get rank: %w", err)
+		}
+
+		st := &leaderboard_domain.Standing{Total: total.Val()}
+		st.Rank = ranks[i].Val() + 1
+		st.UserID = userID
+		st.Score = int64(-scores[i].Val())
+		standings[userID] = st
+	}
+
+	return standings, nil
+}
+
+func (b *Board) Around(ctx context.Context, userID uuid.UUID, window int) ([]*leaderboard_domain.Entry, error) {
+	res, err := aroundScript.Run(ctx, b.client, []string{b.key}, userID.String(), window).Slice()
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, leaderboard_domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	return page(res)
+}
+
+// page reads the {first index, {member, score, ...}} reply of the scripts
+func page(res []interface{}) ([]*leaderboard_domain.Entry, error) {
+	if len(res) != 2 {
+		return nil, fmt.Errorf("unexpected leaderboard reply")
+	}
+
+	first, ok := res[0].(int64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected leaderboard reply")
+	}
+	flat, ok := res[1].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected leaderboard reply")
+	}
+
+	entries := make([]*leaderboard_domain.Entry, 0, len(flat)/2)
+	for i := 0; i+1 < len(flat); i += 2 {
+		s, _ := flat[i+1].(string)
+		score, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid leaderboard score %q", s)
+		}
+
+		e, err := entry(first+int64(i/2)+1, flat[i], score)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+func entry(rank int64, member interface{}, score float64) (*leaderboard_domain.Entry, error) {
+	s, _ := member.(string)
+
+	userID, err := uuid.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid leaderboard member %q", s)
+	}
+
+	return &leaderboard_domain.Entry{Rank: rank, UserID: userID, Score: int64(-score)}, nil
+}
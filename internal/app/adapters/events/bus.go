@@ -0,0 +1,43 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+)
+
+// Bus is an in-process event_domain.Publisher, handlers run synchronously
+// in the publishing goroutine in the order they subscribed
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]event_domain.Handler
+	log      *slog.Logger
+}
+
+func New(log *slog.Logger) *Bus {
+	return &Bus{
+		handlers: make(map[string][]event_domain.Handler),
+		log:      log,
+	}
+}
+
+func (b *Bus) Subscribe(eventType string, h event_domain.Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], h)
+}
+
+func (b *Bus) Publish(ctx context.Context, e event_domain.Event) {
+	b.mu.RLock()
+	handlers := b.handlers[e.Type]
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		if err := h(ctx, e); err != nil {
+			b.log.Error("event handler failed", "type", e.Type, "user_id", e.UserID, "err", err)
+		}
+	}
+}
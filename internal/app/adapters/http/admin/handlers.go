@@ -0,0 +1,794 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/middlewares"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/utils"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/achievement_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/quest_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/season_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/store_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/achievement_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/quest_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/score_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/season_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/store_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/task_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/verification_usecase"
+)
+
+var (
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+type AdminHandler struct {
+	TaskService         task_usecase.Service
+	VerificationService verification_usecase.Service
+	QuestService        quest_usecase.Service
+	ScoreService        score_usecase.Service
+	StoreService        store_usecase.Service
+	AchievementService  achievement_usecase.Service
+	SeasonService       season_usecase.Service
+	Logger              *slog.Logger
+}
+
+func NewAdminHandler(
+	ts task_usecase.Service,
+	vs verification_usecase.Service,
+	qs quest_usecase.Service,
+	ss score_usecase.Service,
+	sts store_usecase.Service,
+	as achievement_usecase.Service,
+	ses season_usecase.Service,
+	log *slog.Logger,
+) *AdminHandler {
+	return &AdminHandler{
+		TaskService:         ts,
+		VerificationService: vs,
+		QuestService:        qs,
+		ScoreService:        ss,
+		StoreService:        sts,
+		AchievementService:  as,
+		SeasonService:       ses,
+		Logger:              log,
+	}
+}
+
+func (h *AdminHandler) ListTasks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		tasks, err := h.TaskService.ListTasks(ctx)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status": "success",
+			"tasks":  tasks,
+		})
+	}
+}
+
+func (h *AdminHandler) CreateTask() http.HandlerFunc {
+	type request struct {
+		Slug          string     `json:"slug"`
+		Title         string     `json:"title"`
+		Description   string     `json:"description"`
+		Reward        int64      `json:"reward"`
+		Active        *bool      `json:"active"`
+		Verifier      string     `json:"verifier"`
+		Recurrence    string     `json:"recurrence"`
+		StartsAt      *time.Time `json:"starts_at"`
+		EndsAt        *time.Time `json:"ends_at"`
+		Prerequisites []int64    `json:"prerequisites"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		t := &task_domain.Task{
+			Slug:        req.Slug,
+			Title:       req.Title,
+			Description: req.Description,
+			Reward:      req.Reward,
+			Active:      req.Active == nil || *req.Active,
+			Verifier:    req.Verifier,
+			Recurrence:  req.Recurrence,
+			StartsAt:    req.StartsAt,
+			EndsAt:      req.EndsAt,
+		}
+		t.Prerequisites = req.Prerequisites
+
+		if err := h.TaskService.CreateTask(ctx, t); err != nil {
+			utils.ErrorFunc(w, r, taskErrorCode(err), err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusCreated, map[string]interface{}{
+			"status": "success",
+			"task":   t,
+		})
+	}
+}
+
+func (h *AdminHandler) UpdateTask(taskID int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPatch {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		req := task_usecase.TaskUpdate{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		t, err := h.TaskService.UpdateTask(ctx, taskID, req)
+		if err != nil {
+			utils.ErrorFunc(w, r, taskErrorCode(err), err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status": "success",
+			"task":   t,
+		})
+	}
+}
+
+func (h *AdminHandler) DeactivateTask(taskID int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if err := h.TaskService.DeactivateTask(ctx, taskID); err != nil {
+			utils.ErrorFunc(w, r, taskErrorCode(err), err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]string{"status": "success"})
+	}
+}
+
+func (h *AdminHandler) AssignTask(taskID int64) http.HandlerFunc {
+	type request struct {
+		UserIDs []uuid.UUID `json:"user_ids"`
+		All     bool        `json:"all"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*30)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		n, err := h.TaskService.AssignTask(ctx, taskID, req.UserIDs, req.All)
+		if err != nil {
+			utils.ErrorFunc(w, r, taskErrorCode(err), err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":   "success",
+			"assigned": n,
+		})
+	}
+}
+
+func (h *AdminHandler) ListVerifications() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		list, err := h.VerificationService.List(ctx, r.URL.Query().Get("status"))
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":        "success",
+			"verifications": list,
+		})
+	}
+}
+
+func (h *AdminHandler) DecideVerification(userID uuid.UUID, task string, decision string) http.HandlerFunc {
+	type request struct {
+		Reason string `json:"reason"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		req := &request{}
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		if err := h.VerificationService.Decide(ctx, userID, task, decision, req.Reason); err != nil {
+			utils.ErrorFunc(w, r, verificationErrorCode(err), err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]string{"status": "success"})
+	}
+}
+
+func (h *AdminHandler) ListQuests() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		quests, err := h.QuestService.ListQuests(ctx)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status": "success",
+			"quests": quests,
+		})
+	}
+}
+
+func (h *AdminHandler) CreateQuest() http.HandlerFunc {
+	type request struct {
+		Slug        string  `json:"slug"`
+		Title       string  `json:"title"`
+		Description string  `json:"description"`
+		Bonus       int64   `json:"bonus"`
+		Active      *bool   `json:"active"`
+		Steps       []int64 `json:"steps"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		q := &quest_domain.Quest{
+			Slug:        req.Slug,
+			Title:       req.Title,
+			Description: req.Description,
+			Bonus:       req.Bonus,
+			Active:      req.Active == nil || *req.Active,
+			Steps:       req.Steps,
+		}
+
+		if err := h.QuestService.CreateQuest(ctx, q); err != nil {
+			utils.ErrorFunc(w, r, questErrorCode(err), err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusCreated, map[string]interface{}{
+			"status": "success",
+			"quest":  q,
+		})
+	}
+}
+
+func (h *AdminHandler) UpdateQuest(questID int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPatch {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		req := quest_usecase.QuestUpdate{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		q, err := h.QuestService.UpdateQuest(ctx, questID, req)
+		if err != nil {
+			utils.ErrorFunc(w, r, questErrorCode(err), err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status": "success",
+			"quest":  q,
+		})
+	}
+}
+
+func (h *AdminHandler) AdjustScore(userID uuid.UUID) http.HandlerFunc {
+	type request struct {
+		Amount int64  `json:"amount"`
+		Reason string `json:"reason"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		actorID, ok := ctx.Value(middlewares.CtxKeyUser).(uuid.UUID)
+		if !ok {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("access denied"))
+			return
+		}
+
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		entry, err := h.ScoreService.Adjust(ctx, &score_domain.Adjustment{
+			UserID:  userID,
+			ActorID: actorID,
+			Amount:  req.Amount,
+			Comment: req.Reason,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, score_domain.ErrUserNotFound):
+				utils.ErrorFunc(w, r, http.StatusNotFound, err)
+			case errors.Is(err, score_domain.ErrNegativeScore), errors.Is(err, score_domain.ErrInsufficientBalance):
+				utils.ErrorFunc(w, r, http.StatusConflict, err)
+			default:
+				utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			}
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusCreated, map[string]interface{}{
+			"status":     "success",
+			"adjustment": entry,
+		})
+	}
+}
+
+func (h *AdminHandler) ListRewardItems() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		items, err := h.StoreService.ListItems(ctx, false)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status": "success",
+			"items":  items,
+		})
+	}
+}
+
+func (h *AdminHandler) CreateRewardItem() http.HandlerFunc {
+	type request struct {
+		Slug         string `json:"slug"`
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		Cost         int64  `json:"cost"`
+		Stock        *int64 `json:"stock"`
+		PerUserLimit *int64 `json:"per_user_limit"`
+		Active       *bool  `json:"active"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		item := &store_domain.Item{
+			Slug:         req.Slug,
+			Title:        req.Title,
+			Description:  req.Description,
+			Cost:         req.Cost,
+			Stock:        req.Stock,
+			PerUserLimit: req.PerUserLimit,
+			Active:       req.Active == nil || *req.Active,
+		}
+
+		if err := h.StoreService.CreateItem(ctx, item); err != nil {
+			utils.ErrorFunc(w, r, storeErrorCode(err), err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusCreated, map[string]interface{}{
+			"status": "success",
+			"item":   item,
+		})
+	}
+}
+
+func (h *AdminHandler) UpdateRewardItem(itemID int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPatch {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		req := store_usecase.ItemUpdate{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		item, err := h.StoreService.UpdateItem(ctx, itemID, req)
+		if err != nil {
+			utils.ErrorFunc(w, r, storeErrorCode(err), err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status": "success",
+			"item":   item,
+		})
+	}
+}
+
+func (h *AdminHandler) ListRedemptions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		list, err := h.StoreService.ListRedemptions(ctx, r.URL.Query().Get("status"))
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":      "success",
+			"redemptions": list,
+		})
+	}
+}
+
+func (h *AdminHandler) DecideRedemption(redemptionID int64, status string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		rd, err := h.StoreService.Decide(ctx, redemptionID, status)
+		if err != nil {
+			utils.ErrorFunc(w, r, storeErrorCode(err), err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":     "success",
+			"redemption": rd,
+		})
+	}
+}
+
+func (h *AdminHandler) ListAchievements() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		achievements, err := h.AchievementService.ListAchievements(ctx)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":       "success",
+			"achievements": achievements,
+		})
+	}
+}
+
+func (h *AdminHandler) CreateAchievement() http.HandlerFunc {
+	type request struct {
+		Slug        string `json:"slug"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Metric      string `json:"metric"`
+		Threshold   int64  `json:"threshold"`
+		Active      *bool  `json:"active"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		a := &achievement_domain.Achievement{
+			Slug:        req.Slug,
+			Title:       req.Title,
+			Description: req.Description,
+			Metric:      req.Metric,
+			Threshold:   req.Threshold,
+			Active:      req.Active == nil || *req.Active,
+		}
+
+		if err := h.AchievementService.CreateAchievement(ctx, a); err != nil {
+			if errors.Is(err, achievement_domain.ErrSlugTaken) {
+				utils.ErrorFunc(w, r, http.StatusConflict, err)
+				return
+			}
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusCreated, map[string]interface{}{
+			"status":      "success",
+			"achievement": a,
+		})
+	}
+}
+
+// ReconcileAchievements unlocks the achievements users have reached but
+// missed
+func (h *AdminHandler) ReconcileAchievements() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*30)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		unlocked, err := h.AchievementService.Reconcile(ctx)
+		if err != nil {
+			h.Logger.Error("failed to reconcile achievements", "error", err)
+			utils.ErrorFunc(w, r, http.StatusInternalServerError, fmt.Errorf("internal server error"))
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":   "success",
+			"unlocked": unlocked,
+		})
+	}
+}
+
+func (h *AdminHandler) ListSeasons() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		seasons, err := h.SeasonService.ListSeasons(ctx)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":  "success",
+			"seasons": seasons,
+		})
+	}
+}
+
+func (h *AdminHandler) CreateSeason() http.HandlerFunc {
+	type request struct {
+		Slug     string                  `json:"slug"`
+		Title    string                  `json:"title"`
+		StartsAt time.Time               `json:"starts_at"`
+		EndsAt   time.Time               `json:"ends_at"`
+		Rewards  []*season_domain.Reward `json:"rewards"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		season := &season_domain.Season{
+			Slug:     req.Slug,
+			Title:    req.Title,
+			StartsAt: req.StartsAt,
+			EndsAt:   req.EndsAt,
+			Rewards:  req.Rewards,
+		}
+		if season.Rewards == nil {
+			season.Rewards = []*season_domain.Reward{}
+		}
+
+		if err := h.SeasonService.CreateSeason(ctx, season); err != nil {
+			if errors.Is(err, season_domain.ErrSlugTaken) || errors.Is(err, season_domain.ErrOverlap) {
+				utils.ErrorFunc(w, r, http.StatusConflict, err)
+				return
+			}
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusCreated, map[string]interface{}{
+			"status": "success",
+			"season": season,
+		})
+	}
+}
+
+func verificationErrorCode(err error) int {
+	switch {
+	case errors.Is(err, task_domain.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, verification_domain.ErrNotPending):
+		return http.StatusConflict
+	case errors.Is(err, task_domain.ErrInactive):
+		return http.StatusGone
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func taskErrorCode(err error) int {
+	switch {
+	case errors.Is(err, task_domain.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, task_domain.ErrSlugTaken), errors.Is(err, task_domain.ErrCycle):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func questErrorCode(err error) int {
+	switch {
+	case errors.Is(err, quest_domain.ErrQuestNotFound), errors.Is(err, task_domain.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, quest_domain.ErrSlugTaken), errors.Is(err, task_domain.ErrCycle):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func storeErrorCode(err error) int {
+	switch {
+	case errors.Is(err, store_domain.ErrItemNotFound), errors.Is(err, store_domain.ErrRedemptionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, store_domain.ErrSlugTaken), errors.Is(err, store_domain.ErrInvalidTransition):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -88,7 +88,7 @@ func (h *AuthHandler) Login() http.HandlerFunc {
 			return
 		}
 
-		accessToken, refreshToken, err := h.AuthService.IssueTokens(ctx, u.UserID)
+		accessToken, refreshToken, err := h.AuthService.IssueTokens(ctx, u.UserID, u.Role)
 		if err != nil {
 			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
 			return
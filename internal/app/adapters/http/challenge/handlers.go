@@ -0,0 +1,43 @@
+package challenge
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/utils"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/challenge_usecase"
+)
+
+var (
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+type ChallengeHandler struct {
+	ChallengeService challenge_usecase.Service
+	Logger           *slog.Logger
+}
+
+func NewChallengeHandler(cs challenge_usecase.Service, log *slog.Logger) *ChallengeHandler {
+	return &ChallengeHandler{
+		ChallengeService: cs,
+		Logger:           log,
+	}
+}
+
+func (h *ChallengeHandler) Challenge() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		c, err := h.ChallengeService.Issue(utils.ClientIP(r))
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, c)
+	}
+}
@@ -4,27 +4,79 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/achievement_usecase"
 	"github.com/vo1dFl0w/users-service/internal/app/usecase/auth_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/challenge_usecase"
 	"github.com/vo1dFl0w/users-service/internal/app/usecase/jwt_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/leaderboard_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/notification_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/quest_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/score_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/season_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/store_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/streak_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/task_usecase"
 	"github.com/vo1dFl0w/users-service/internal/app/usecase/user_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/verification_usecase"
 )
 
 type Handler struct {
-	Router      *http.ServeMux
-	Root        http.Handler
-	Logger      *slog.Logger
-	JWTService  jwt.Service
-	AuthService auth_usecase.Service
-	UserService user_usecase.Service
+	Router              *http.ServeMux
+	Root                http.Handler
+	Logger              *slog.Logger
+	JWTService          jwt.Service
+	AuthService         auth_usecase.Service
+	UserService         user_usecase.Service
+	ChallengeService    challenge_usecase.Service
+	TaskService         task_usecase.Service
+	VerificationService verification_usecase.Service
+	QuestService        quest_usecase.Service
+	ScoreService        score_usecase.Service
+	StoreService        store_usecase.Service
+	AchievementService  achievement_usecase.Service
+	StreakService       streak_usecase.Service
+	LeaderboardService  leaderboard_usecase.Service
+	LeaderboardFeed     *leaderboard_usecase.Feed
+	SeasonService       season_usecase.Service
+	NotificationHub     *notification_usecase.Hub
 }
 
-func NewHandler(log *slog.Logger, token jwt.Service, auth auth_usecase.Service, user user_usecase.Service) *Handler {
+func NewHandler(
+	log *slog.Logger,
+	token jwt.Service,
+	auth auth_usecase.Service,
+	user user_usecase.Service,
+	challenge challenge_usecase.Service,
+	task task_usecase.Service,
+	verification verification_usecase.Service,
+	quest quest_usecase.Service,
+	score score_usecase.Service,
+	store store_usecase.Service,
+	achievement achievement_usecase.Service,
+	streak streak_usecase.Service,
+	leaderboard leaderboard_usecase.Service,
+	leaderboardFeed *leaderboard_usecase.Feed,
+	season season_usecase.Service,
+	notifications *notification_usecase.Hub,
+) *Handler {
 	h := &Handler{
-		Router:      http.NewServeMux(),
-		Logger:      log,
-		JWTService:  token,
-		AuthService: auth,
-		UserService: user,
+		Router:              http.NewServeMux(),
+		Logger:              log,
+		JWTService:          token,
+		AuthService:         auth,
+		UserService:         user,
+		ChallengeService:    challenge,
+		TaskService:         task,
+		VerificationService: verification,
+		QuestService:        quest,
+		ScoreService:        score,
+		StoreService:        store,
+		AchievementService:  achievement,
+		StreakService:       streak,
+		LeaderboardService:  leaderboard,
+		LeaderboardFeed:     leaderboardFeed,
+		SeasonService:       season,
+		NotificationHub:     notifications,
 	}
 
 	h.Routes()
@@ -22,9 +22,10 @@ func New(secret []byte) *JWTService {
 	}
 }
 
-func (s *JWTService) GenerateAccessToken(userID uuid.UUID) (string, error) {
+func (s *JWTService) GenerateAccessToken(userID uuid.UUID, role string) (string, error) {
 	t := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt_usecase.TokenClaims{
 		UserID: userID,
+		Role:   role,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(time.Minute * 15).Unix(),
 			IssuedAt:  time.Now().Unix(),
@@ -0,0 +1,256 @@
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/middlewares"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/utils"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/leaderboard_usecase"
+)
+
+var (
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+type LeaderboardHandler struct {
+	LeaderboardService leaderboard_usecase.Service
+	Feed               *leaderboard_usecase.Feed
+	Logger             *slog.Logger
+}
+
+func NewLeaderboardHandler(ls leaderboard_usecase.Service, feed *leaderboard_usecase.Feed, log *slog.Logger) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		LeaderboardService: ls,
+		Feed:               feed,
+		Logger:             log,
+	}
+}
+
+func (h *LeaderboardHandler) Top() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if _, ok := ctx.Value(middlewares.CtxKeyUser).(uuid.UUID); !ok {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("access denied"))
+			return
+		}
+
+		limit, err := queryInt(r, "limit")
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		period := leaderboard_domain.Period{
+			Name: r.URL.Query().Get("period"),
+			From: r.URL.Query().Get("from"),
+			To:   r.URL.Query().Get("to"),
+		}
+
+		entries, next, err := h.LeaderboardService.Top(ctx, period, r.URL.Query().Get("cursor"), limit)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":      "success",
+			"entries":     entries,
+			"next_cursor": next,
+		})
+	}
+}
+
+func (h *LeaderboardHandler) Rank(userID uuid.UUID) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if err := middlewares.CheckUserOrAdmin(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		standing, err := h.LeaderboardService.Rank(ctx, userID)
+		if err != nil {
+			if errors.Is(err, leaderboard_domain.ErrUserNotFound) {
+				utils.ErrorFunc(w, r, http.StatusNotFound, err)
+				return
+			}
+			h.Logger.Error("failed to get rank", "error", err)
+			utils.ErrorFunc(w, r, http.StatusInternalServerError, fmt.Errorf("internal server error"))
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":     "success",
+			"rank":       standing.Rank,
+			"score":      standing.Score,
+			"total":      standing.Total,
+			"percentile": standing.Percentile,
+		})
+	}
+}
+
+func (h *LeaderboardHandler) Around(userID uuid.UUID) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if _, ok := ctx.Value(middlewares.CtxKeyUser).(uuid.UUID); !ok {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("access denied"))
+			return
+		}
+
+		window, err := queryInt(r, "window")
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		entries, err := h.LeaderboardService.Around(ctx, userID, window)
+		if err != nil {
+			if errors.Is(err, leaderboard_domain.ErrUserNotFound) {
+				utils.ErrorFunc(w, r, http.StatusNotFound, err)
+				return
+			}
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":  "success",
+			"entries": entries,
+		})
+	}
+}
+
+// Stream sends the top of the leaderboard and the caller's rank as
+// Server-Sent Events whenever they change
+func (h *LeaderboardHandler) Stream() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		userID, ok := ctx.Value(middlewares.CtxKeyUser).(uuid.UUID)
+		if !ok {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("access denied"))
+			return
+		}
+
+		sub, err := h.Feed.Subscribe(ctx, userID)
+		if err != nil {
+			h.Logger.Error("failed to subscribe to leaderboard", "error", err)
+			utils.ErrorFunc(w, r, http.StatusInternalServerError, fmt.Errorf("internal server error"))
+			return
+		}
+		defer sub.Close()
+
+		rc := http.NewResponseController(w)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		heartbeat := time.NewTicker(h.Feed.Heartbeat())
+		defer heartbeat.Stop()
+
+		// the stream ends with the token, the client reconnects with a fresh one
+		var expired <-chan time.Time
+		if expiry, ok := middlewares.Expiry(ctx); ok {
+			timer := time.NewTimer(time.Until(expiry))
+			defer timer.Stop()
+			expired = timer.C
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-expired:
+				if err := writeEvent(w, "expired", map[string]interface{}{}); err == nil {
+					rc.Flush()
+				}
+				return
+			case <-heartbeat.C:
+				if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			case <-sub.Ready():
+				top, rank := sub.Next()
+				if top != nil {
+					if err := writeEvent(w, "top", map[string]interface{}{"entries": top}); err != nil {
+						return
+					}
+				}
+				if rank != nil {
+					if err := writeEvent(w, "rank", rank); err != nil {
+						return
+					}
+				}
+			}
+
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeEvent(w io.Writer, event string, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+
+	return err
+}
+
+// queryInt returns the integer query parameter, 0 when it is not set
+func queryInt(r *http.Request, name string) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s", name)
+	}
+
+	return n, nil
+}
@@ -7,38 +7,77 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/utils"
 	jwt_usecase "github.com/vo1dFl0w/users-service/internal/app/usecase/jwt_usecase"
 )
 
+// WebSocketProtocol is the subprotocol a browser offers together with its
+// access token, it cannot set headers on a WebSocket handshake
+const WebSocketProtocol = "bearer"
+
 func AuthMiddleware(jwtServ jwt_usecase.Service) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
-			ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+			token, err := accessToken(r)
+			if err != nil {
+				utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+				return
+			}
+
+			// the timeout only covers the check, streaming handlers outlive it
+			checkCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 			defer cancel()
 
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("missing authorization"))
+			claims, err := jwtServ.ValidateAccessToken(checkCtx, token)
+			if err != nil {
+				utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("invalid or expired access token"))
 				return
 			}
 
-			parts := strings.Fields(authHeader)
-			if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
-				utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("invalid authorization header"))
-				return
+			ctx = context.WithValue(ctx, CtxKeyUser, claims.UserID)
+			ctx = context.WithValue(ctx, CtxKeyRole, claims.Role)
+			if claims.ExpiresAt != 0 {
+				ctx = context.WithValue(ctx, CtxKeyExpiry, time.Unix(claims.ExpiresAt, 0))
 			}
-			token := parts[1]
 
-			claims, err := jwtServ.ValidateAccessToken(ctx, token)
-			if err != nil {
-				utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("invalid or expired access token"))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// accessToken takes the token from the Authorization header, a WebSocket
+// handshake without it may offer the "bearer, <token>" subprotocols instead
+func accessToken(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		if websocket.IsWebSocketUpgrade(r) {
+			if protocols := websocket.Subprotocols(r); len(protocols) == 2 && protocols[0] == WebSocketProtocol {
+				return protocols[1], nil
+			}
+		}
+		return "", fmt.Errorf("missing authorization")
+	}
+
+	parts := strings.Fields(authHeader)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return "", fmt.Errorf("invalid authorization header")
+	}
+
+	return parts[1], nil
+}
+
+func RoleMiddleware(role string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if v, ok := r.Context().Value(CtxKeyRole).(string); !ok || v != role {
+				utils.ErrorFunc(w, r, http.StatusForbidden, fmt.Errorf("access denied"))
 				return
 			}
 
-			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, CtxKeyUser, claims.UserID)))
+			next.ServeHTTP(w, r)
 		})
 	}
 }
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/utils"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/challenge_usecase"
+)
+
+const (
+	HeaderChallenge = "X-PoW-Challenge"
+	HeaderSolution  = "X-PoW-Solution"
+)
+
+func ChallengeMiddleware(challengeServ challenge_usecase.Service) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := utils.ClientIP(r)
+
+			difficulty := challengeServ.Hit(ip)
+			if difficulty == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			err := challengeServ.Verify(ip, r.Header.Get(HeaderChallenge), r.Header.Get(HeaderSolution), difficulty)
+			if err != nil {
+				if errors.Is(err, challenge_usecase.ErrChallengeRequired) {
+					utils.ErrorFunc(w, r, http.StatusPreconditionRequired, err)
+					return
+				}
+				utils.ErrorFunc(w, r, http.StatusForbidden, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
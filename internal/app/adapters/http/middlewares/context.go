@@ -1,7 +1,51 @@
 package middlewares
 
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/auth_domain"
+)
+
+var ErrAccessDenied = errors.New("access denied")
+
 type ctxKey string
 
 const (
-	CtxKeyUser ctxKey = "user"
-)
\ No newline at end of file
+	CtxKeyUser   ctxKey = "user"
+	CtxKeyRole   ctxKey = "role"
+	CtxKeyExpiry ctxKey = "expiry"
+)
+
+// UserID returns the user authenticated by AuthMiddleware
+func UserID(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(CtxKeyUser).(uuid.UUID)
+	return userID, ok
+}
+
+// Expiry returns when the access token of the request expires, long-lived
+// connections have to end by then
+func Expiry(ctx context.Context) (time.Time, bool) {
+	expiry, ok := ctx.Value(CtxKeyExpiry).(time.Time)
+	return expiry, ok
+}
+
+// CheckUser lets only the user named in the path act on their account
+func CheckUser(ctx context.Context, userID uuid.UUID) error {
+	if authUser, ok := UserID(ctx); !ok || authUser != userID {
+		return ErrAccessDenied
+	}
+
+	return nil
+}
+
+// CheckUserOrAdmin lets admins view the account as well
+func CheckUserOrAdmin(ctx context.Context, userID uuid.UUID) error {
+	if role, ok := ctx.Value(CtxKeyRole).(string); ok && role == auth_domain.RoleAdmin {
+		return nil
+	}
+
+	return CheckUser(ctx, userID)
+}
@@ -1,8 +1,10 @@
 package middlewares
 
 import (
+	"bufio"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"time"
 )
@@ -57,3 +59,18 @@ func (rw *responseWriter) WriteHeader(statusCode int) {
 	rw.code = statusCode
 	rw.ResponseWriter.WriteHeader(statusCode)
 }
+
+// Unwrap lets http.ResponseController reach the flusher of streaming responses
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Hijack hands the connection over to WebSocket upgrades
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := http.NewResponseController(rw.ResponseWriter).Hijack()
+	if err == nil {
+		rw.code = http.StatusSwitchingProtocols
+	}
+
+	return conn, brw, err
+}
@@ -0,0 +1,205 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/middlewares"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/utils"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/notification_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/notification_usecase"
+)
+
+var (
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+const (
+	writeWait      = time.Second * 10
+	maxMessageSize = 4096
+)
+
+type NotificationHandler struct {
+	Hub      *notification_usecase.Hub
+	Upgrader websocket.Upgrader
+	Logger   *slog.Logger
+}
+
+func NewNotificationHandler(hub *notification_usecase.Hub, log *slog.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		Hub: hub,
+		Upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// a browser that passed its token as a subprotocol needs one
+			// accepted back, the token itself is never echoed
+			Subprotocols: []string{middlewares.WebSocketProtocol},
+		},
+		Logger: log,
+	}
+}
+
+// Connect upgrades to a WebSocket that carries the user's notifications
+func (h *NotificationHandler) Connect() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		userID, ok := ctx.Value(middlewares.CtxKeyUser).(uuid.UUID)
+		if !ok {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("access denied"))
+			return
+		}
+
+		var cursor *notification_domain.Cursor
+		if v := r.URL.Query().Get("cursor"); v != "" {
+			c, err := notification_domain.ParseCursor(v)
+			if err != nil {
+				utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+				return
+			}
+			cursor = c
+		}
+
+		var topics []string
+		if v := r.URL.Query().Get("topics"); v != "" {
+			topics = strings.Split(v, ",")
+		}
+		if err := notification_domain.ValidateTopics(topics); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		connectCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+		defer cancel()
+
+		client, err := h.Hub.Connect(connectCtx, userID, cursor, topics)
+		if err != nil {
+			h.Logger.Error("failed to connect to notifications", "error", err)
+			utils.ErrorFunc(w, r, http.StatusInternalServerError, fmt.Errorf("internal server error"))
+			return
+		}
+		defer client.Close()
+
+		// Upgrade replies by itself when it fails
+		conn, err := h.Upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// the connection ends with the token, the client reconnects with a
+		// fresh one and its cursor
+		var expired <-chan time.Time
+		if expiry, ok := middlewares.Expiry(ctx); ok {
+			timer := time.NewTimer(time.Until(expiry))
+			defer timer.Stop()
+			expired = timer.C
+		}
+
+		go h.read(ctx, conn, client)
+		h.write(conn, client, expired)
+	}
+}
+
+// read handles the client's requests until the connection fails, anything
+// from the client including pongs keeps the connection alive
+func (h *NotificationHandler) read(ctx context.Context, conn *websocket.Conn, client *notification_usecase.Client) {
+	defer client.Close()
+
+	pongWait := h.Hub.Ping() * 2
+	alive := func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	}
+
+	conn.SetReadLimit(maxMessageSize)
+	conn.SetPongHandler(alive)
+	if err := alive(""); err != nil {
+		return
+	}
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if err := alive(""); err != nil {
+			return
+		}
+
+		if err := h.handle(ctx, client, msg); err != nil {
+			client.Push(notification_domain.New(notification_domain.TypeError, client.UserID, notification_domain.Error{
+				Error: err.Error(),
+			}))
+		}
+	}
+}
+
+func (h *NotificationHandler) handle(ctx context.Context, client *notification_usecase.Client, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var req notification_domain.Request
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return fmt.Errorf("invalid request")
+	}
+
+	switch req.Type {
+	case notification_domain.RequestPing:
+		client.Push(notification_domain.New(notification_domain.TypePong, client.UserID, nil))
+		return nil
+	case notification_domain.RequestSubscribe:
+		return client.Subscribe(ctx, req.Topics)
+	case notification_domain.RequestUnsubscribe:
+		return client.Unsubscribe(req.Topics)
+	default:
+		return fmt.Errorf("invalid request type %q, expected one of: %s, %s, %s", req.Type,
+			notification_domain.RequestSubscribe, notification_domain.RequestUnsubscribe, notification_domain.RequestPing)
+	}
+}
+
+// write is the only writer of the connection, it sends the client's queue
+// and the pings and says why the connection ends
+func (h *NotificationHandler) write(conn *websocket.Conn, client *notification_usecase.Client, expired <-chan time.Time) {
+	ticker := time.NewTicker(h.Hub.Ping())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case n := <-client.Send():
+			if err := conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				return
+			}
+			if err := conn.WriteJSON(n); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+		case <-expired:
+			_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "token expired"), time.Now().Add(writeWait))
+			return
+		case <-client.Done():
+			code, text := websocket.CloseNormalClosure, ""
+			// a dropped client may reconnect with its cursor and catch up
+			if err := client.Err(); err != nil {
+				code, text = websocket.CloseTryAgainLater, err.Error()
+			}
+			_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(writeWait))
+			return
+		}
+	}
+}
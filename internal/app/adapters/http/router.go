@@ -3,24 +3,54 @@ package http_adaptor
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/admin"
 	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/auth"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/challenge"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/leaderboard"
 	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/middlewares"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/notification"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/season"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/store"
 	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/user"
 	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/utils"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/verification"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/auth_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/store_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
 )
 
 func (h *Handler) Routes() http.Handler {
 	authHandler := auth.NewAuthHandler(h.AuthService, h.Logger)
 
-	userHandler := user.NewUserHandler(h.UserService, h.Logger)
+	userHandler := user.NewUserHandler(h.UserService, h.ScoreService, h.AchievementService, h.StreakService, h.Logger)
+
+	challengeHandler := challenge.NewChallengeHandler(h.ChallengeService, h.Logger)
+
+	adminHandler := admin.NewAdminHandler(h.TaskService, h.VerificationService, h.QuestService, h.ScoreService, h.StoreService, h.AchievementService, h.SeasonService, h.Logger)
+
+	verificationHandler := verification.NewVerificationHandler(h.VerificationService, h.Logger)
+
+	storeHandler := store.NewStoreHandler(h.StoreService, h.Logger)
+
+	leaderboardHandler := leaderboard.NewLeaderboardHandler(h.LeaderboardService, h.LeaderboardFeed, h.Logger)
+
+	seasonHandler := season.NewSeasonHandler(h.SeasonService, h.Logger)
+
+	notificationHandler := notification.NewNotificationHandler(h.NotificationHub, h.Logger)
 
 	h.Root = middlewares.LoggerMiddleware(h.Logger)(h.Router)
 
-	h.Router.HandleFunc("/register", authHandler.Register())
-	h.Router.HandleFunc("/login", authHandler.Login())
+	pow := middlewares.ChallengeMiddleware(h.ChallengeService)
+
+	h.Router.HandleFunc("/challenge", challengeHandler.Challenge())
+	h.Router.Handle("/register", pow(authHandler.Register()))
+	h.Router.Handle("/login", pow(authHandler.Login()))
+
+	h.Router.HandleFunc("/callbacks/verifications", verificationHandler.Callback())
 
 	authorized := http.NewServeMux()
 	authorized.Handle("/users/", middlewares.AuthMiddleware(h.JWTService)(
@@ -28,7 +58,28 @@ func (h *Handler) Routes() http.Handler {
 			parts := parseURL(r.URL.Path)
 
 			if len(parts) == 2 && parts[0] == "users" && parts[1] == "leaderboard" {
-				userHandler.Leaderboard()(w, r)
+				leaderboardHandler.Top()(w, r)
+				return
+			}
+
+			if len(parts) == 2 && parts[0] == "users" && parts[1] == "notifications" {
+				notificationHandler.Connect()(w, r)
+				return
+			}
+
+			if len(parts) == 3 && parts[0] == "users" && parts[1] == "leaderboard" && parts[2] == "stream" {
+				leaderboardHandler.Stream()(w, r)
+				return
+			}
+
+			if len(parts) == 4 && parts[0] == "users" && parts[1] == "leaderboard" && parts[2] == "around" {
+				userID, err := parseUUID(parts[3])
+				if err != nil {
+					utils.ErrorFunc(w, r, http.StatusUnprocessableEntity, err)
+					return
+				}
+
+				leaderboardHandler.Around(userID)(w, r)
 				return
 			}
 
@@ -46,6 +97,37 @@ func (h *Handler) Routes() http.Handler {
 				case "referrer":
 					userHandler.Refferer(userID)(w, r)
 					return
+				case "tasks":
+					userHandler.UserTasks(userID)(w, r)
+					return
+				case "quests":
+					userHandler.UserQuests(userID)(w, r)
+					return
+				case "achievements":
+					userHandler.UserAchievements(userID)(w, r)
+					return
+				case "transfers":
+					userHandler.Transfer(userID)(w, r)
+					return
+				case "redemptions":
+					if r.Method == http.MethodGet {
+						storeHandler.UserRedemptions(userID)(w, r)
+						return
+					}
+					storeHandler.Redeem(userID)(w, r)
+					return
+				case "telegram":
+					userHandler.LinkTelegram(userID)(w, r)
+					return
+				case "timezone":
+					userHandler.SetTimezone(userID)(w, r)
+					return
+				case "rank":
+					leaderboardHandler.Rank(userID)(w, r)
+					return
+				case "seasons":
+					seasonHandler.UserSeasons(userID)(w, r)
+					return
 				default:
 					utils.ErrorFunc(w, r, http.StatusBadRequest, fmt.Errorf("unknown endpoint"))
 					return
@@ -62,10 +144,209 @@ func (h *Handler) Routes() http.Handler {
 				userHandler.CompleteTask(userID)(w, r)
 				return
 			}
+
+			if len(parts) == 4 && parts[0] == "users" && parts[2] == "score" && parts[3] == "history" {
+				userID, err := parseUUID(parts[1])
+				if err != nil {
+					utils.ErrorFunc(w, r, http.StatusUnprocessableEntity, err)
+					return
+				}
+
+				userHandler.ScoreHistory(userID)(w, r)
+				return
+			}
+
+			if len(parts) == 4 && parts[0] == "users" && parts[2] == "streak" && parts[3] == "freeze" {
+				userID, err := parseUUID(parts[1])
+				if err != nil {
+					utils.ErrorFunc(w, r, http.StatusUnprocessableEntity, err)
+					return
+				}
+
+				userHandler.FreezeStreak(userID)(w, r)
+				return
+			}
 		}),
 	))
 	h.Router.Handle("/users/", authorized)
 
+	h.Router.Handle("/rewards", middlewares.AuthMiddleware(h.JWTService)(storeHandler.Items()))
+
+	h.Router.Handle("/seasons", middlewares.AuthMiddleware(h.JWTService)(seasonHandler.Seasons()))
+	h.Router.Handle("/seasons/", middlewares.AuthMiddleware(h.JWTService)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			parts := parseURL(r.URL.Path)
+
+			if len(parts) == 3 && parts[2] == "leaderboard" {
+				seasonID, err := parseSeasonID(parts[1])
+				if err != nil {
+					utils.ErrorFunc(w, r, http.StatusUnprocessableEntity, err)
+					return
+				}
+
+				seasonHandler.Leaderboard(seasonID)(w, r)
+				return
+			}
+
+			utils.ErrorFunc(w, r, http.StatusNotFound, fmt.Errorf("unknown endpoint"))
+		}),
+	))
+
+	h.Router.Handle("/admin/", middlewares.AuthMiddleware(h.JWTService)(middlewares.RoleMiddleware(auth_domain.RoleAdmin)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			parts := parseURL(r.URL.Path)
+
+			if len(parts) == 2 && parts[1] == "tasks" {
+				if r.Method == http.MethodGet {
+					adminHandler.ListTasks()(w, r)
+					return
+				}
+				adminHandler.CreateTask()(w, r)
+				return
+			}
+
+			if len(parts) >= 3 && parts[1] == "tasks" {
+				taskID, err := parseTaskID(parts[2])
+				if err != nil {
+					utils.ErrorFunc(w, r, http.StatusUnprocessableEntity, err)
+					return
+				}
+
+				switch {
+				case len(parts) == 3:
+					adminHandler.UpdateTask(taskID)(w, r)
+					return
+				case len(parts) == 4 && parts[3] == "deactivate":
+					adminHandler.DeactivateTask(taskID)(w, r)
+					return
+				case len(parts) == 4 && parts[3] == "assign":
+					adminHandler.AssignTask(taskID)(w, r)
+					return
+				}
+			}
+
+			if len(parts) == 2 && parts[1] == "quests" {
+				if r.Method == http.MethodGet {
+					adminHandler.ListQuests()(w, r)
+					return
+				}
+				adminHandler.CreateQuest()(w, r)
+				return
+			}
+
+			if len(parts) == 3 && parts[1] == "quests" {
+				questID, err := parseQuestID(parts[2])
+				if err != nil {
+					utils.ErrorFunc(w, r, http.StatusUnprocessableEntity, err)
+					return
+				}
+
+				adminHandler.UpdateQuest(questID)(w, r)
+				return
+			}
+
+			if len(parts) == 4 && parts[1] == "users" && parts[3] == "score-adjustments" {
+				userID, err := parseUUID(parts[2])
+				if err != nil {
+					utils.ErrorFunc(w, r, http.StatusUnprocessableEntity, err)
+					return
+				}
+
+				adminHandler.AdjustScore(userID)(w, r)
+				return
+			}
+
+			if len(parts) == 2 && parts[1] == "rewards" {
+				if r.Method == http.MethodGet {
+					adminHandler.ListRewardItems()(w, r)
+					return
+				}
+				adminHandler.CreateRewardItem()(w, r)
+				return
+			}
+
+			if len(parts) == 3 && parts[1] == "rewards" {
+				itemID, err := parseItemID(parts[2])
+				if err != nil {
+					utils.ErrorFunc(w, r, http.StatusUnprocessableEntity, err)
+					return
+				}
+
+				adminHandler.UpdateRewardItem(itemID)(w, r)
+				return
+			}
+
+			if len(parts) == 2 && parts[1] == "achievements" {
+				if r.Method == http.MethodGet {
+					adminHandler.ListAchievements()(w, r)
+					return
+				}
+				adminHandler.CreateAchievement()(w, r)
+				return
+			}
+
+			if len(parts) == 3 && parts[1] == "achievements" && parts[2] == "reconcile" {
+				adminHandler.ReconcileAchievements()(w, r)
+				return
+			}
+
+			if len(parts) == 2 && parts[1] == "seasons" {
+				if r.Method == http.MethodGet {
+					adminHandler.ListSeasons()(w, r)
+					return
+				}
+				adminHandler.CreateSeason()(w, r)
+				return
+			}
+
+			if len(parts) == 2 && parts[1] == "redemptions" {
+				adminHandler.ListRedemptions()(w, r)
+				return
+			}
+
+			if len(parts) == 4 && parts[1] == "redemptions" {
+				redemptionID, err := parseRedemptionID(parts[2])
+				if err != nil {
+					utils.ErrorFunc(w, r, http.StatusUnprocessableEntity, err)
+					return
+				}
+
+				switch parts[3] {
+				case "fulfill":
+					adminHandler.DecideRedemption(redemptionID, store_domain.StatusFulfilled)(w, r)
+					return
+				case "refund":
+					adminHandler.DecideRedemption(redemptionID, store_domain.StatusRefunded)(w, r)
+					return
+				}
+			}
+
+			if len(parts) == 2 && parts[1] == "verifications" {
+				adminHandler.ListVerifications()(w, r)
+				return
+			}
+
+			if len(parts) == 5 && parts[1] == "verifications" {
+				userID, err := parseUUID(parts[2])
+				if err != nil {
+					utils.ErrorFunc(w, r, http.StatusUnprocessableEntity, err)
+					return
+				}
+
+				switch parts[4] {
+				case "approve":
+					adminHandler.DecideVerification(userID, parts[3], verification_domain.StatusVerified)(w, r)
+					return
+				case "reject":
+					adminHandler.DecideVerification(userID, parts[3], verification_domain.StatusRejected)(w, r)
+					return
+				}
+			}
+
+			utils.ErrorFunc(w, r, http.StatusNotFound, fmt.Errorf("unknown endpoint"))
+		}),
+	)))
+
 	return h.Router
 }
 
@@ -91,3 +372,48 @@ func parseUUID(userID string) (uuid.UUID, error) {
 
 	return id, nil
 }
+
+func parseTaskID(taskID string) (int64, error) {
+	id, err := strconv.ParseInt(taskID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid task_id")
+	}
+
+	return id, nil
+}
+
+func parseQuestID(questID string) (int64, error) {
+	id, err := strconv.ParseInt(questID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid quest_id")
+	}
+
+	return id, nil
+}
+
+func parseItemID(itemID string) (int64, error) {
+	id, err := strconv.ParseInt(itemID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid item_id")
+	}
+
+	return id, nil
+}
+
+func parseRedemptionID(redemptionID string) (int64, error) {
+	id, err := strconv.ParseInt(redemptionID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid redemption_id")
+	}
+
+	return id, nil
+}
+
+func parseSeasonID(seasonID string) (int64, error) {
+	id, err := strconv.ParseInt(seasonID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid season_id")
+	}
+
+	return id, nil
+}
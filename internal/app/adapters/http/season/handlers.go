@@ -0,0 +1,123 @@
+package season
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/middlewares"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/utils"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/season_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/season_usecase"
+)
+
+var (
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+type SeasonHandler struct {
+	SeasonService season_usecase.Service
+	Logger        *slog.Logger
+}
+
+func NewSeasonHandler(ss season_usecase.Service, log *slog.Logger) *SeasonHandler {
+	return &SeasonHandler{
+		SeasonService: ss,
+		Logger:        log,
+	}
+}
+
+func (h *SeasonHandler) Seasons() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		seasons, err := h.SeasonService.ListSeasons(ctx)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":  "success",
+			"seasons": seasons,
+		})
+	}
+}
+
+func (h *SeasonHandler) Leaderboard(seasonID int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		var limit int
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				utils.ErrorFunc(w, r, http.StatusBadRequest, fmt.Errorf("invalid limit"))
+				return
+			}
+			limit = n
+		}
+
+		season, entries, next, err := h.SeasonService.Leaderboard(ctx, seasonID, r.URL.Query().Get("cursor"), limit)
+		if err != nil {
+			if errors.Is(err, season_domain.ErrSeasonNotFound) {
+				utils.ErrorFunc(w, r, http.StatusNotFound, err)
+				return
+			}
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":      "success",
+			"season":      season,
+			"entries":     entries,
+			"next_cursor": next,
+		})
+	}
+}
+
+func (h *SeasonHandler) UserSeasons(userID uuid.UUID) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if err := middlewares.CheckUserOrAdmin(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		standings, err := h.SeasonService.UserSeasons(ctx, userID)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":  "success",
+			"seasons": standings,
+		})
+	}
+}
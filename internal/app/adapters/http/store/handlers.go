@@ -0,0 +1,141 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/middlewares"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/utils"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/store_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/store_usecase"
+)
+
+var (
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+type StoreHandler struct {
+	StoreService store_usecase.Service
+	Logger       *slog.Logger
+}
+
+func NewStoreHandler(ss store_usecase.Service, log *slog.Logger) *StoreHandler {
+	return &StoreHandler{
+		StoreService: ss,
+		Logger:       log,
+	}
+}
+
+func (h *StoreHandler) Items() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		items, err := h.StoreService.ListItems(ctx, true)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status": "success",
+			"items":  items,
+		})
+	}
+}
+
+func (h *StoreHandler) Redeem(userID uuid.UUID) http.HandlerFunc {
+	type request struct {
+		ItemID int64 `json:"item_id"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if err := middlewares.CheckUser(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		rd, err := h.StoreService.Redeem(ctx, userID, req.ItemID)
+		if err != nil {
+			utils.ErrorFunc(w, r, errorCode(err), err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusCreated, map[string]interface{}{
+			"status":     "success",
+			"redemption": rd,
+		})
+	}
+}
+
+func (h *StoreHandler) UserRedemptions(userID uuid.UUID) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if err := middlewares.CheckUserOrAdmin(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		list, err := h.StoreService.UserRedemptions(ctx, userID)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":      "success",
+			"redemptions": list,
+		})
+	}
+}
+
+// errorCode maps store errors to HTTP status codes
+func errorCode(err error) int {
+	switch {
+	case errors.Is(err, store_domain.ErrItemNotFound),
+		errors.Is(err, store_domain.ErrRedemptionNotFound),
+		errors.Is(err, score_domain.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, store_domain.ErrSlugTaken),
+		errors.Is(err, store_domain.ErrItemUnavailable),
+		errors.Is(err, store_domain.ErrOutOfStock),
+		errors.Is(err, store_domain.ErrLimitReached),
+		errors.Is(err, store_domain.ErrInvalidTransition),
+		errors.Is(err, score_domain.ErrInsufficientBalance):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
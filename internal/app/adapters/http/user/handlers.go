@@ -7,11 +7,20 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/middlewares"
 	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/utils"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/achievement_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/score_usecase"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/streak_usecase"
 	"github.com/vo1dFl0w/users-service/internal/app/usecase/user_usecase"
 )
 
@@ -20,14 +29,26 @@ var (
 )
 
 type UserHandler struct {
-	UserService user_usecase.Service
-	Logger      *slog.Logger
+	UserService        user_usecase.Service
+	ScoreService       score_usecase.Service
+	AchievementService achievement_usecase.Service
+	StreakService      streak_usecase.Service
+	Logger             *slog.Logger
 }
 
-func NewUserHandler(us user_usecase.Service, log *slog.Logger) *UserHandler {
+func NewUserHandler(
+	us user_usecase.Service,
+	ss score_usecase.Service,
+	as achievement_usecase.Service,
+	sts streak_usecase.Service,
+	log *slog.Logger,
+) *UserHandler {
 	return &UserHandler{
-		UserService: us,
-		Logger:      log,
+		UserService:        us,
+		ScoreService:       ss,
+		AchievementService: as,
+		StreakService:      sts,
+		Logger:             log,
 	}
 }
 
@@ -41,32 +62,59 @@ func (h *UserHandler) GetUserStatus(userID uuid.UUID) http.HandlerFunc {
 			return
 		}
 
-		authUser, ok := getUserID(ctx)
-		if !ok {
-			utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("access denied"))
+		if err := middlewares.CheckUserOrAdmin(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		u, err := h.UserService.UserStatus(ctx, userID)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":         "success",
+			"user_id":        u.UserID,
+			"score":          u.Score,
+			"spendable":      u.Spendable,
+			"streak":         u.Streak,
+			"longest_streak": u.LongestStreak,
+			"streak_freezes": u.StreakFreezes,
+			"level":          u.Level,
+		})
+	}
+}
+
+func (h *UserHandler) UserTasks(userID uuid.UUID) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
 			return
 		}
 
-		if err := compareUserID(authUser, userID); err != nil {
+		if err := middlewares.CheckUserOrAdmin(ctx, userID); err != nil {
 			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
 			return
 		}
 
-		u, err := h.UserService.UserStatus(ctx, userID)
+		tasks, err := h.UserService.UserTasks(ctx, userID, r.URL.Query().Get("state"))
 		if err != nil {
 			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
 			return
 		}
 
 		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
-			"status":  "success",
-			"user_id": u.UserID,
-			"score":   u.Score,
+			"status": "success",
+			"tasks":  tasks,
 		})
 	}
 }
 
-func (h *UserHandler) Leaderboard() http.HandlerFunc {
+func (h *UserHandler) ScoreHistory(userID uuid.UUID) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
 		defer cancel()
@@ -76,43 +124,208 @@ func (h *UserHandler) Leaderboard() http.HandlerFunc {
 			return
 		}
 
-		_, ok := getUserID(ctx)
-		if !ok {
-			utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("access denied"))
+		if err := middlewares.CheckUserOrAdmin(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
 			return
 		}
 
-		res, err := h.UserService.Leaderboard(ctx)
+		var (
+			cursor int64
+			limit  int
+			err    error
+		)
+		if v := r.URL.Query().Get("cursor"); v != "" {
+			if cursor, err = strconv.ParseInt(v, 10, 64); err != nil {
+				utils.ErrorFunc(w, r, http.StatusBadRequest, fmt.Errorf("invalid cursor"))
+				return
+			}
+		}
+		if v := r.URL.Query().Get("limit"); v != "" {
+			if limit, err = strconv.Atoi(v); err != nil {
+				utils.ErrorFunc(w, r, http.StatusBadRequest, fmt.Errorf("invalid limit"))
+				return
+			}
+		}
+
+		entries, next, err := h.ScoreService.History(ctx, userID, cursor, limit)
 		if err != nil {
 			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
 			return
 		}
 
-		utils.RespondFunc(w, r, http.StatusOK, res)
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":      "success",
+			"entries":     entries,
+			"next_cursor": next,
+		})
 	}
 }
 
-func (h *UserHandler) CompleteTask(userID uuid.UUID) http.HandlerFunc {
+func (h *UserHandler) Transfer(userID uuid.UUID) http.HandlerFunc {
 	type request struct {
-		Task string `json:"task"`
+		RecipientID uuid.UUID `json:"recipient_id"`
+		Amount      int64     `json:"amount"`
+		Comment     string    `json:"comment"`
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
 		defer cancel()
 
-		if r.Method != http.MethodPatch {
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if err := middlewares.CheckUser(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		t := &score_domain.Transfer{
+			SenderID:    userID,
+			RecipientID: req.RecipientID,
+			Amount:      req.Amount,
+			Comment:     req.Comment,
+		}
+
+		if err := h.ScoreService.Transfer(ctx, t); err != nil {
+			switch {
+			case errors.Is(err, score_domain.ErrUserNotFound):
+				utils.ErrorFunc(w, r, http.StatusNotFound, err)
+			case errors.Is(err, score_domain.ErrInsufficientBalance):
+				utils.ErrorFunc(w, r, http.StatusConflict, err)
+			case errors.Is(err, score_domain.ErrAccountTooNew):
+				utils.ErrorFunc(w, r, http.StatusForbidden, err)
+			case errors.Is(err, score_domain.ErrDailyLimit):
+				utils.ErrorFunc(w, r, http.StatusTooManyRequests, err)
+			default:
+				utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			}
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusCreated, map[string]interface{}{
+			"status":   "success",
+			"transfer": t,
+		})
+	}
+}
+
+func (h *UserHandler) UserQuests(userID uuid.UUID) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if err := middlewares.CheckUserOrAdmin(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		quests, err := h.UserService.UserQuests(ctx, userID)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status": "success",
+			"quests": quests,
+		})
+	}
+}
+
+func (h *UserHandler) UserAchievements(userID uuid.UUID) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if err := middlewares.CheckUserOrAdmin(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		achievements, err := h.AchievementService.UserAchievements(ctx, userID)
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status":       "success",
+			"achievements": achievements,
+		})
+	}
+}
+
+func (h *UserHandler) FreezeStreak(userID uuid.UUID) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
 			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
 			return
 		}
 
-		authUser, ok := getUserID(ctx)
-		if !ok {
-			utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("access denied"))
+		if err := middlewares.CheckUser(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		st, err := h.StreakService.Freeze(ctx, userID)
+		if err != nil {
+			switch {
+			case errors.Is(err, score_domain.ErrUserNotFound):
+				utils.ErrorFunc(w, r, http.StatusNotFound, err)
+			case errors.Is(err, streak_domain.ErrNoFreezes),
+				errors.Is(err, streak_domain.ErrNothingToCover),
+				errors.Is(err, streak_domain.ErrStreakLost):
+				utils.ErrorFunc(w, r, http.StatusConflict, err)
+			default:
+				utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			}
 			return
 		}
 
-		if err := compareUserID(authUser, userID); err != nil {
+		utils.RespondFunc(w, r, http.StatusOK, map[string]interface{}{
+			"status": "success",
+			"streak": st,
+		})
+	}
+}
+
+func (h *UserHandler) CompleteTask(userID uuid.UUID) http.HandlerFunc {
+	type request struct {
+		Task string `json:"task"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPatch {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if err := middlewares.CheckUser(ctx, userID); err != nil {
 			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
 			return
 		}
@@ -123,12 +336,40 @@ func (h *UserHandler) CompleteTask(userID uuid.UUID) http.HandlerFunc {
 			return
 		}
 
-		if err := h.UserService.CompleteUserTask(ctx, userID, req.Task); err != nil {
-			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+		c, err := h.UserService.CompleteUserTask(ctx, userID, req.Task, r.Header.Get("Idempotency-Key"))
+		if err != nil {
+			switch {
+			case errors.Is(err, task_domain.ErrTaskNotFound):
+				utils.ErrorFunc(w, r, http.StatusNotFound, err)
+			case errors.Is(err, user_domain.ErrIdempotencyKeyReused):
+				utils.ErrorFunc(w, r, http.StatusUnprocessableEntity, err)
+			case errors.Is(err, verification_domain.ErrNotPending), errors.Is(err, task_domain.ErrNotStarted),
+				errors.Is(err, task_domain.ErrLocked):
+				utils.ErrorFunc(w, r, http.StatusConflict, err)
+			case errors.Is(err, task_domain.ErrExpired), errors.Is(err, task_domain.ErrInactive):
+				utils.ErrorFunc(w, r, http.StatusGone, err)
+			default:
+				utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			}
 			return
 		}
 
-		utils.RespondFunc(w, r, http.StatusOK, map[string]string{"status": "success"})
+		code := http.StatusOK
+		if c.Status == verification_domain.StatusPending {
+			code = http.StatusAccepted
+		}
+
+		utils.RespondFunc(w, r, code, map[string]interface{}{
+			"status":              "success",
+			"task":                c.Task,
+			"reward":              c.Reward,
+			"awarded":             c.Awarded,
+			"period":              c.Period,
+			"completed_at":        c.CompletedAt,
+			"verification_status": c.Status,
+			"verification_reason": c.Reason,
+			"quests":              c.Quests,
+		})
 	}
 }
 
@@ -147,13 +388,7 @@ func (h *UserHandler) Refferer(userID uuid.UUID) http.HandlerFunc {
 			return
 		}
 
-		authUser, ok := getUserID(ctx)
-		if !ok {
-			utils.ErrorFunc(w, r, http.StatusUnauthorized, fmt.Errorf("access denied"))
-			return
-		}
-
-		if err := compareUserID(authUser, userID); err != nil {
+		if err := middlewares.CheckUser(ctx, userID); err != nil {
 			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
 			return
 		}
@@ -173,16 +408,94 @@ func (h *UserHandler) Refferer(userID uuid.UUID) http.HandlerFunc {
 	}
 }
 
-func getUserID(ctx context.Context) (uuid.UUID, bool) {
-	v := ctx.Value(middlewares.CtxKeyUser)
-	s, ok := v.(uuid.UUID)
-	return s, ok
+// LinkTelegram takes the user object of the Telegram Login widget as is,
+// its fields are signed as strings so numbers keep their literal form
+func (h *UserHandler) LinkTelegram(userID uuid.UUID) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPut {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if err := middlewares.CheckUser(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		req := map[string]interface{}{}
+		dec := json.NewDecoder(r.Body)
+		dec.UseNumber()
+		if err := dec.Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		login := make(map[string]string, len(req))
+		for k, v := range req {
+			switch v := v.(type) {
+			case string:
+				login[k] = v
+			case json.Number:
+				login[k] = v.String()
+			default:
+				utils.ErrorFunc(w, r, http.StatusBadRequest, fmt.Errorf("invalid telegram login field %q", k))
+				return
+			}
+		}
+
+		if err := h.UserService.LinkTelegram(ctx, userID, login); err != nil {
+			switch {
+			case errors.Is(err, user_domain.ErrTelegramLinked):
+				utils.ErrorFunc(w, r, http.StatusConflict, err)
+				return
+			case errors.Is(err, user_domain.ErrTelegramLoginInvalid), errors.Is(err, user_domain.ErrTelegramLoginExpired):
+				utils.ErrorFunc(w, r, http.StatusForbidden, err)
+				return
+			case errors.Is(err, user_domain.ErrTelegramDisabled):
+				utils.ErrorFunc(w, r, http.StatusServiceUnavailable, err)
+				return
+			}
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]string{"status": "success"})
+	}
 }
 
-func compareUserID(idStr uuid.UUID, userID uuid.UUID) error {
-	if idStr != userID {
-		return fmt.Errorf("access denied")
+func (h *UserHandler) SetTimezone(userID uuid.UUID) http.HandlerFunc {
+	type request struct {
+		Timezone string `json:"timezone"`
 	}
 
-	return nil
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPut {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		if err := middlewares.CheckUser(ctx, userID); err != nil {
+			utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := h.UserService.SetTimezone(ctx, userID, req.Timezone); err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]string{"status": "success"})
+	}
 }
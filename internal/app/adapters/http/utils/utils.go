@@ -2,6 +2,7 @@ package utils
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 )
 
@@ -16,4 +17,13 @@ func RespondFunc(w http.ResponseWriter, r *http.Request, code int, data interfac
 	if data != nil {
 		json.NewEncoder(w).Encode(data)
 	}
-}
\ No newline at end of file
+}
+
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,73 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/http/utils"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/verification_usecase"
+)
+
+const HeaderSignature = "X-Signature"
+
+var (
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+type VerificationHandler struct {
+	VerificationService verification_usecase.Service
+	Logger              *slog.Logger
+}
+
+func NewVerificationHandler(vs verification_usecase.Service, log *slog.Logger) *VerificationHandler {
+	return &VerificationHandler{
+		VerificationService: vs,
+		Logger:              log,
+	}
+}
+
+func (h *VerificationHandler) Callback() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), time.Second*5)
+		defer cancel()
+
+		if r.Method != http.MethodPost {
+			utils.ErrorFunc(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<16))
+		if err != nil {
+			utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := h.VerificationService.Callback(ctx, body, r.Header.Get(HeaderSignature)); err != nil {
+			switch {
+			case errors.Is(err, verification_usecase.ErrInvalidSignature),
+				errors.Is(err, verification_usecase.ErrCallbacksDisabled):
+				utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			case errors.Is(err, task_domain.ErrTaskNotFound):
+				utils.ErrorFunc(w, r, http.StatusNotFound, err)
+			case errors.Is(err, verification_usecase.ErrStaleCallback),
+				errors.Is(err, verification_domain.ErrNonceUsed):
+				utils.ErrorFunc(w, r, http.StatusUnauthorized, err)
+			case errors.Is(err, verification_domain.ErrNotPending):
+				utils.ErrorFunc(w, r, http.StatusConflict, err)
+			case errors.Is(err, task_domain.ErrInactive):
+				utils.ErrorFunc(w, r, http.StatusGone, err)
+			default:
+				utils.ErrorFunc(w, r, http.StatusBadRequest, err)
+			}
+			return
+		}
+
+		utils.RespondFunc(w, r, http.StatusOK, map[string]string{"status": "success"})
+	}
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/achievement_domain"
+)
+
+type Achievement struct {
+	DB *sql.DB
+}
+
+func (a *Achievement) CreateAchievement(ctx context.Context, achievement *achievement_domain.Achievement) error {
+	if err := a.DB.QueryRowContext(ctx,
+		`INSERT INTO achievements (slug, title, description, metric, threshold, active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING achievement_id, created_at`,
+		achievement.Slug, achievement.Title, achievement.Description, achievement.Metric, achievement.Threshold, achievement.Active,
+	).Scan(&achievement.AchievementID, &achievement.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return achievement_domain.ErrSlugTaken
+		}
+		return fmt.Errorf("failed to create achievement: %w", err)
+	}
+
+	return nil
+}
+
+func (a *Achievement) ListAchievements(ctx context.Context) ([]*achievement_domain.Achievement, error) {
+	rows, err := a.DB.QueryContext(ctx,
+		`SELECT achievement_id, slug, title, description, metric, threshold, active, created_at
+		FROM achievements ORDER BY achievement_id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list achievements: %w", err)
+	}
+	defer rows.Close()
+
+	achievements := []*achievement_domain.Achievement{}
+	for rows.Next() {
+		ach := &achievement_domain.Achievement{}
+		if err := rows.Scan(&ach.AchievementID, &ach.Slug, &ach.Title, &ach.Description, &ach.Metric, &ach.Threshold, &ach.Active, &ach.CreatedAt); err != nil {
+			return nil, err
+		}
+		achievements = append(achievements, ach)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return achievements, nil
+}
+
+func (a *Achievement) Unlock(ctx context.Context, userID uuid.UUID) ([]*achievement_domain.UserAchievement, error) {
+	return a.userAchievements(ctx,
+		`WITH metrics AS (
+			SELECT
+				COALESCE((SELECT score FROM users_scoreboard WHERE user_id = $1), 0) AS score,
+				(SELECT COUNT(*) FROM users_tasks_completions WHERE user_id = $1) AS tasks_completed,
+				(SELECT COUNT(DISTINCT user_id) FROM users_tasks WHERE referrer_id = $1) AS referrals,
+				COALESCE((SELECT current FROM users_streaks WHERE user_id = $1), 0) AS streak
+		),
+		unlocked AS (
+			INSERT INTO users_achievements (user_id, achievement_id)
+			SELECT $1, a.achievement_id
+			FROM achievements a, metrics m
+			WHERE a.active AND a.threshold <= CASE a.metric
+				WHEN 'score' THEN m.score
+				WHEN 'tasks_completed' THEN m.tasks_completed
+				WHEN 'referrals' THEN m.referrals
+				WHEN 'streak' THEN m.streak
+			END
+			ON CONFLICT (user_id, achievement_id) DO NOTHING
+			RETURNING achievement_id, unlocked_at
+		)
+		SELECT a.achievement_id, a.slug, a.title, a.description, a.metric, a.threshold, u.unlocked_at
+		FROM unlocked u
+		JOIN achievements a USING (achievement_id)
+		ORDER BY a.achievement_id`,
+		userID,
+	)
+}
+
+func (a *Achievement) UnlockAll(ctx context.Context) ([]*achievement_domain.UserAchievement, error) {
+	rows, err := a.DB.QueryContext(ctx,
+		`WITH metrics AS (
+			SELECT
+				u.user_id,
+				COALESCE(sb.score, 0) AS score,
+				(SELECT COUNT(*) FROM users_tasks_completions c WHERE c.user_id = u.user_id) AS tasks_completed,
+				(SELECT COUNT(DISTINCT ut.user_id) FROM users_tasks ut WHERE ut.referrer_id = u.user_id) AS referrals,
+				COALESCE(st.current, 0) AS streak
+			FROM users u
+			LEFT JOIN users_scoreboard sb USING (user_id)
+			LEFT JOIN users_streaks st USING (user_id)
+		),
+		unlocked AS (
+			INSERT INTO users_achievements (user_id, achievement_id)
+			SELECT m.user_id, a.achievement_id
+			FROM achievements a, metrics m
+			WHERE a.active AND a.threshold <= CASE a.metric
+				WHEN 'score' THEN m.score
+				WHEN 'tasks_completed' THEN m.tasks_completed
+				WHEN 'referrals' THEN m.referrals
+				WHEN 'streak' THEN m.streak
+			END
+			ON CONFLICT (user_id, achievement_id) DO NOTHING
+			RETURNING user_id, achievement_id, unlocked_at
+		)
+		SELECT u.user_id, a.achievement_id, a.slug, a.title, a.description, a.metric, a.threshold, u.unlocked_at
+		FROM unlocked u
+		JOIN achievements a USING (achievement_id)
+		ORDER BY u.user_id, a.achievement_id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock achievements: %w", err)
+	}
+	defer rows.Close()
+
+	achievements := []*achievement_domain.UserAchievement{}
+	for rows.Next() {
+		ach := &achievement_domain.UserAchievement{}
+		if err := rows.Scan(&ach.UserID, &ach.AchievementID, &ach.Slug, &ach.Title, &ach.Description, &ach.Metric, &ach.Threshold, &ach.UnlockedAt); err != nil {
+			return nil, err
+		}
+		achievements = append(achievements, ach)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return achievements, nil
+}
+
+func (a *Achievement) UserAchievements(ctx context.Context, userID uuid.UUID) ([]*achievement_domain.UserAchievement, error) {
+	return a.userAchievements(ctx,
+		`SELECT a.achievement_id, a.slug, a.title, a.description, a.metric, a.threshold, ua.unlocked_at
+		FROM users_achievements ua
+		JOIN achievements a USING (achievement_id)
+		WHERE ua.user_id = $1
+		ORDER BY ua.unlocked_at, a.achievement_id`,
+		userID,
+	)
+}
+
+func (a *Achievement) userAchievements(ctx context.Context, query string, userID uuid.UUID) ([]*achievement_domain.UserAchievement, error) {
+	rows, err := a.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user achievements: %w", err)
+	}
+	defer rows.Close()
+
+	achievements := []*achievement_domain.UserAchievement{}
+	for rows.Next() {
+		ach := &achievement_domain.UserAchievement{}
+		if err := rows.Scan(&ach.AchievementID, &ach.Slug, &ach.Title, &ach.Description, &ach.Metric, &ach.Threshold, &ach.UnlockedAt); err != nil {
+			return nil, err
+		}
+		achievements = append(achievements, ach)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return achievements, nil
+}
@@ -11,18 +11,11 @@ import (
 	"github.com/vo1dFl0w/users-service/internal/app/domain/auth_domain"
 )
 
-var (
-	subscrubeTelegramTask  = "subscribe to 'telegram' channel/group"
-	subscrubeInstagramTask = "subscribe to 'instagram' account"
-	subscrubeVKTask        = "subscribe to 'vkontakte' group"
-	reward                 = 150
-)
-
 type Auth struct {
 	DB *sql.DB
 }
 
-func (a *Auth) CreateUser(ctx context.Context, email string, encryptedPassword string) error {
+func (a *Auth) CreateUser(ctx context.Context, email string, encryptedPassword string) (err error) {
 	tx, err := a.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
 		return fmt.Errorf("failed to start create user transaction: %w", err)
@@ -39,59 +32,18 @@ func (a *Auth) CreateUser(ctx context.Context, email string, encryptedPassword s
 	}()
 
 	var userID uuid.UUID
-	if err := tx.QueryRowContext(ctx,
+	if err = tx.QueryRowContext(ctx,
 		"INSERT INTO users (email, encrypted_password, created_at) VALUES ($1, $2, NOW()) RETURNING user_id",
 		email, encryptedPassword,
 	).Scan(&userID); err != nil {
 		return fmt.Errorf("failed to create new user: %w", err)
 	}
 
-	task1, err := tx.ExecContext(ctx,
-		"INSERT INTO users_tasks (user_id, task, reward) VALUES ($1, $2, $3)",
-		userID, subscrubeTelegramTask, reward,
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to create new task to user: %w", err)
-	}
-
-	r, err := task1.RowsAffected()
-	if err == nil {
-		if r == 0 {
-			return fmt.Errorf("no row added")
-		}
-	}
-
-	task2, err := tx.ExecContext(ctx,
-		"INSERT INTO users_tasks (user_id, task, reward) VALUES ($1, $2, $3)",
-		userID, subscrubeInstagramTask, reward,
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to create new task to user: %w", err)
-	}
-
-	r, err = task2.RowsAffected()
-	if err == nil {
-		if r == 0 {
-			return fmt.Errorf("no row added")
-		}
-	}
-
-	task3, err := tx.ExecContext(ctx,
-		"INSERT INTO users_tasks (user_id, task, reward) VALUES ($1, $2, $3)",
-		userID, subscrubeVKTask, reward,
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to create new task to user: %w", err)
-	}
-
-	r, err = task3.RowsAffected()
-	if err == nil {
-		if r == 0 {
-			return fmt.Errorf("no row added")
-		}
+	if _, err = tx.ExecContext(ctx,
+		"INSERT INTO users_tasks (user_id, task_id) SELECT $1, task_id FROM tasks WHERE active",
+		userID,
+	); err != nil {
+		return fmt.Errorf("failed to assign tasks to user: %w", err)
 	}
 
 	row, err := tx.ExecContext(ctx,
@@ -103,13 +55,17 @@ func (a *Auth) CreateUser(ctx context.Context, email string, encryptedPassword s
 		return fmt.Errorf("failed to create new user in users_scoreboard table: %w", err)
 	}
 
-	r, err = row.RowsAffected()
+	r, err := row.RowsAffected()
 	if err == nil {
 		if r == 0 {
 			return fmt.Errorf("no row added")
 		}
 	}
 
+	if _, err = tx.ExecContext(ctx, "UPDATE users_scoreboard_size SET total = total + 1"); err != nil {
+		return fmt.Errorf("failed to update scoreboard size: %w", err)
+	}
+
 	return nil
 }
 
@@ -117,9 +73,9 @@ func (a *Auth) GetUser(ctx context.Context, email string) (*auth_domain.User, er
 	u := &auth_domain.User{}
 
 	err := a.DB.QueryRowContext(ctx,
-		"SELECT user_id, encrypted_password FROM users WHERE email = $1",
+		"SELECT user_id, encrypted_password, role FROM users WHERE email = $1",
 		email,
-	).Scan(&u.UserID, &u.EncryptedPassword)
+	).Scan(&u.UserID, &u.EncryptedPassword, &u.Role)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("user not found")
@@ -0,0 +1,311 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+)
+
+type Leaderboard struct {
+	DB *sql.DB
+}
+
+func (l *Leaderboard) Top(ctx context.Context, window *leaderboard_domain.Window, after *leaderboard_domain.Cursor, limit int) (entries []*leaderboard_domain.Entry, err error) {
+	if window != nil {
+		return l.topWindow(ctx, window, after, limit)
+	}
+
+	if after == nil {
+		rows, err := l.DB.QueryContext(ctx,
+			"SELECT user_id, score FROM users_scoreboard ORDER BY score DESC, user_id LIMIT $1",
+			limit,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+		}
+		return scanEntries(rows, 1, 1)
+	}
+
+	// the page and the places ahead of it are read from the same snapshot
+	tx, err := l.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start 'leaderboard' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	ahead, err := countAhead(ctx, tx, after)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cursor itself is ahead of the page
+	return below(ctx, tx, after, ahead+2, limit)
+}
+
+// topWindow ranks users by the score they earned within the window, users
+// who earned nothing there are left out. Season rewards are paid after a
+// season ends and do not count towards the next one
+func (l *Leaderboard) topWindow(ctx context.Context, window *leaderboard_domain.Window, after *leaderboard_domain.Cursor, limit int) ([]*leaderboard_domain.Entry, error) {
+	var (
+		score  sql.NullInt64
+		userID uuid.NullUUID
+	)
+	if after != nil {
+		score = sql.NullInt64{Int64: after.Score, Valid: true}
+		userID = uuid.NullUUID{UUID: after.UserID, Valid: true}
+	}
+
+	rows, err := l.DB.QueryContext(ctx,
+		`SELECT user_id, score, rank FROM (
+			SELECT user_id, score, ROW_NUMBER() OVER (ORDER BY score DESC, user_id) AS rank
+			FROM (
+				SELECT user_id, SUM(amount) AS score FROM score_ledger
+				WHERE created_at >= $1 AND created_at < $2 AND amount <> 0 AND reason <> $6
+				GROUP BY user_id
+				HAVING SUM(amount) > 0
+			) w
+		) r
+		WHERE $3::BIGINT IS NULL OR score < $3 OR (score = $3 AND user_id > $4)
+		ORDER BY rank
+		LIMIT $5`,
+		window.From, window.To, score, userID, limit, score_domain.ReasonSeason,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*leaderboard_domain.Entry{}
+	for rows.Next() {
+		e := &leaderboard_domain.Entry{}
+		if err := rows.Scan(&e.UserID, &e.Score, &e.Rank); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (l *Leaderboard) Rank(ctx context.Context, userID uuid.UUID) (st *leaderboard_domain.Standing, err error) {
+	tx, err := l.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start 'rank' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	st, err = standing(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the size is kept with every scoreboard insert, counting the rows would
+	// scan the whole table on each lookup
+	if err = tx.QueryRowContext(ctx, "SELECT total FROM users_scoreboard_size").Scan(&st.Total); err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard size: %w", err)
+	}
+
+	return st, nil
+}
+
+// Ranks numbers the whole scoreboard once instead of counting the places
+// ahead of every user
+func (l *Leaderboard) Ranks(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*leaderboard_domain.Standing, error) {
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+
+	rows, err := l.DB.QueryContext(ctx,
+		`SELECT user_id, score, rank, total FROM (
+			SELECT user_id, score, ROW_NUMBER() OVER (ORDER BY score DESC, user_id) AS rank, COUNT(*) OVER () AS total
+			FROM users_scoreboard
+		) r
+		WHERE user_id = ANY($1::uuid[])`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ranks: %w", err)
+	}
+	defer rows.Close()
+
+	standings := make(map[uuid.UUID]*leaderboard_domain.Standing, len(userIDs))
+	for rows.Next() {
+		st := &leaderboard_domain.Standing{}
+		if err := rows.Scan(&st.UserID, &st.Score, &st.Rank, &st.Total); err != nil {
+			return nil, err
+		}
+		standings[st.UserID] = st
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return standings, nil
+}
+
+func (l *Leaderboard) Around(ctx context.Context, userID uuid.UUID, window int) (entries []*leaderboard_domain.Entry, err error) {
+	tx, err := l.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start 'leaderboard around' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	st, err := standing(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	cursor := st.Entry.Cursor()
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT user_id, score FROM users_scoreboard
+		WHERE score > $1 OR (score = $1 AND user_id < $2)
+		ORDER BY score, user_id DESC
+		LIMIT $3`,
+		cursor.Score, cursor.UserID, window,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	above, err := scanEntries(rows, st.Rank-1, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := below(ctx, tx, cursor, st.Rank+1, window)
+	if err != nil {
+		return nil, err
+	}
+
+	entries = make([]*leaderboard_domain.Entry, 0, len(above)+1+len(after))
+	for i := len(above) - 1; i >= 0; i-- {
+		entries = append(entries, above[i])
+	}
+	entries = append(entries, &st.Entry)
+
+	return append(entries, after...), nil
+}
+
+func (l *Leaderboard) Scores(ctx context.Context) ([]*leaderboard_domain.Entry, error) {
+	rows, err := l.DB.QueryContext(ctx, "SELECT user_id, score FROM users_scoreboard")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scores: %w", err)
+	}
+
+	return scanEntries(rows, 0, 0)
+}
+
+// standing returns the user's place, the rank is the number of users ahead
+// counted on the (score DESC, user_id) index plus one
+func standing(ctx context.Context, tx *sql.Tx, userID uuid.UUID) (*leaderboard_domain.Standing, error) {
+	st := &leaderboard_domain.Standing{}
+	st.UserID = userID
+
+	if err := tx.QueryRowContext(ctx,
+		"SELECT score FROM users_scoreboard WHERE user_id = $1",
+		userID,
+	).Scan(&st.Score); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, leaderboard_domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get score: %w", err)
+	}
+
+	ahead, err := countAhead(ctx, tx, st.Entry.Cursor())
+	if err != nil {
+		return nil, err
+	}
+	st.Rank = ahead + 1
+
+	return st, nil
+}
+
+// countAhead returns the number of users ranked before the cursor
+func countAhead(ctx context.Context, tx *sql.Tx, c *leaderboard_domain.Cursor) (int64, error) {
+	var n int64
+
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM users_scoreboard
+		WHERE score > $1 OR (score = $1 AND user_id < $2)`,
+		c.Score, c.UserID,
+	).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count leaderboard places: %w", err)
+	}
+
+	return n, nil
+}
+
+// below returns up to limit entries ranked right after the cursor, the
+// first of them at rank
+func below(ctx context.Context, tx *sql.Tx, c *leaderboard_domain.Cursor, rank int64, limit int) ([]*leaderboard_domain.Entry, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT user_id, score FROM users_scoreboard
+		WHERE score < $1 OR (score = $1 AND user_id > $2)
+		ORDER BY score DESC, user_id
+		LIMIT $3`,
+		c.Score, c.UserID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	return scanEntries(rows, rank, 1)
+}
+
+// scanEntries reads (user_id, score) rows ranking the first one at rank and
+// every next one step further
+func scanEntries(rows *sql.Rows, rank int64, step int64) ([]*leaderboard_domain.Entry, error) {
+	defer rows.Close()
+
+	entries := []*leaderboard_domain.Entry{}
+	for rows.Next() {
+		e := &leaderboard_domain.Entry{Rank: rank}
+		if err := rows.Scan(&e.UserID, &e.Score); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+		rank += step
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/quest_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
+)
+
+type Quest struct {
+	DB *sql.DB
+}
+
+func (q *Quest) CreateQuest(ctx context.Context, quest *quest_domain.Quest) (err error) {
+	tx, err := q.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start 'create quest' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if err = tx.QueryRowContext(ctx,
+		`INSERT INTO quests (slug, title, description, bonus, active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING quest_id, created_at`,
+		quest.Slug, quest.Title, quest.Description, quest.Bonus, quest.Active,
+	).Scan(&quest.QuestID, &quest.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return quest_domain.ErrSlugTaken
+		}
+		return fmt.Errorf("failed to create quest: %w", err)
+	}
+
+	if err = setQuestSteps(ctx, tx, quest.QuestID, quest.Steps); err != nil {
+		return err
+	}
+
+	return checkRequirementCycle(ctx, tx, quest.Steps)
+}
+
+func (q *Quest) GetQuest(ctx context.Context, questID int64) (*quest_domain.Quest, error) {
+	quest := &quest_domain.Quest{}
+
+	if err := q.DB.QueryRowContext(ctx,
+		`SELECT quest_id, slug, title, description, bonus, active, created_at,
+			ARRAY(SELECT task_id FROM quest_steps s WHERE s.quest_id = quests.quest_id ORDER BY position)
+		FROM quests WHERE quest_id = $1`,
+		questID,
+	).Scan(&quest.QuestID, &quest.Slug, &quest.Title, &quest.Description, &quest.Bonus, &quest.Active, &quest.CreatedAt, pq.Array(&quest.Steps)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, quest_domain.ErrQuestNotFound
+		}
+		return nil, fmt.Errorf("failed to get quest: %w", err)
+	}
+
+	return quest, nil
+}
+
+func (q *Quest) UpdateQuest(ctx context.Context, quest *quest_domain.Quest) (err error) {
+	tx, err := q.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start 'update quest' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx,
+		"UPDATE quests SET slug = $1, title = $2, description = $3, bonus = $4, active = $5 WHERE quest_id = $6",
+		quest.Slug, quest.Title, quest.Description, quest.Bonus, quest.Active, quest.QuestID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return quest_domain.ErrSlugTaken
+		}
+		return fmt.Errorf("failed to update quest: %w", err)
+	}
+
+	r, err := res.RowsAffected()
+	if err == nil {
+		if r == 0 {
+			return quest_domain.ErrQuestNotFound
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM quest_steps WHERE quest_id = $1", quest.QuestID); err != nil {
+		return fmt.Errorf("failed to clear quest steps: %w", err)
+	}
+
+	if err = setQuestSteps(ctx, tx, quest.QuestID, quest.Steps); err != nil {
+		return err
+	}
+
+	return checkRequirementCycle(ctx, tx, quest.Steps)
+}
+
+func (q *Quest) ListQuests(ctx context.Context) ([]*quest_domain.Quest, error) {
+	rows, err := q.DB.QueryContext(ctx,
+		`SELECT quest_id, slug, title, description, bonus, active, created_at,
+			ARRAY(SELECT task_id FROM quest_steps s WHERE s.quest_id = quests.quest_id ORDER BY position)
+		FROM quests ORDER BY quest_id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quests: %w", err)
+	}
+	defer rows.Close()
+
+	quests := []*quest_domain.Quest{}
+	for rows.Next() {
+		quest := &quest_domain.Quest{}
+		if err := rows.Scan(&quest.QuestID, &quest.Slug, &quest.Title, &quest.Description, &quest.Bonus, &quest.Active, &quest.CreatedAt, pq.Array(&quest.Steps)); err != nil {
+			return nil, err
+		}
+		quests = append(quests, quest)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return quests, nil
+}
+
+func setQuestSteps(ctx context.Context, tx *sql.Tx, questID int64, steps []int64) error {
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO quest_steps (quest_id, task_id, position)
+		SELECT $1, s.task_id, s.position FROM unnest($2::int[]) WITH ORDINALITY AS s (task_id, position)`,
+		questID, pq.Array(steps),
+	); err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("quest step: %w", task_domain.ErrTaskNotFound)
+		}
+		return fmt.Errorf("failed to set quest steps: %w", err)
+	}
+
+	return nil
+}
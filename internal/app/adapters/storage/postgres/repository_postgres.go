@@ -3,14 +3,32 @@ package postgres
 import (
 	"database/sql"
 
+	"github.com/vo1dFl0w/users-service/internal/app/domain/achievement_domain"
 	"github.com/vo1dFl0w/users-service/internal/app/domain/auth_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/quest_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/season_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/store_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
 	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
 )
 
 type Storage struct {
-	DB *sql.DB
-	authRepository auth_domain.AuthRepository
-	userRepository user_domain.UserRepository
+	DB                     *sql.DB
+	authRepository         auth_domain.AuthRepository
+	userRepository         user_domain.UserRepository
+	taskRepository         task_domain.TaskRepository
+	verificationRepository verification_domain.VerificationRepository
+	questRepository        quest_domain.QuestRepository
+	scoreRepository        score_domain.ScoreRepository
+	storeRepository        store_domain.StoreRepository
+	achievementRepository  achievement_domain.AchievementRepository
+	streakRepository       streak_domain.StreakRepository
+	leaderboardRepository  leaderboard_domain.LeaderboardRepository
+	seasonRepository       season_domain.SeasonRepository
 }
 
 func New(db *sql.DB) *Storage {
@@ -42,3 +60,111 @@ func (s *Storage) User() user_domain.UserRepository {
 
 	return s.userRepository
 }
+
+func (s *Storage) Task() task_domain.TaskRepository {
+	if s.taskRepository != nil {
+		return s.taskRepository
+	}
+
+	s.taskRepository = &Task{
+		DB: s.DB,
+	}
+
+	return s.taskRepository
+}
+
+func (s *Storage) Verification() verification_domain.VerificationRepository {
+	if s.verificationRepository != nil {
+		return s.verificationRepository
+	}
+
+	s.verificationRepository = &Verification{
+		DB: s.DB,
+	}
+
+	return s.verificationRepository
+}
+
+func (s *Storage) Quest() quest_domain.QuestRepository {
+	if s.questRepository != nil {
+		return s.questRepository
+	}
+
+	s.questRepository = &Quest{
+		DB: s.DB,
+	}
+
+	return s.questRepository
+}
+
+func (s *Storage) Score() score_domain.ScoreRepository {
+	if s.scoreRepository != nil {
+		return s.scoreRepository
+	}
+
+	s.scoreRepository = &Score{
+		DB: s.DB,
+	}
+
+	return s.scoreRepository
+}
+
+func (s *Storage) Store() store_domain.StoreRepository {
+	if s.storeRepository != nil {
+		return s.storeRepository
+	}
+
+	s.storeRepository = &Store{
+		DB: s.DB,
+	}
+
+	return s.storeRepository
+}
+
+func (s *Storage) Achievement() achievement_domain.AchievementRepository {
+	if s.achievementRepository != nil {
+		return s.achievementRepository
+	}
+
+	s.achievementRepository = &Achievement{
+		DB: s.DB,
+	}
+
+	return s.achievementRepository
+}
+
+func (s *Storage) Streak() streak_domain.StreakRepository {
+	if s.streakRepository != nil {
+		return s.streakRepository
+	}
+
+	s.streakRepository = &Streak{
+		DB: s.DB,
+	}
+
+	return s.streakRepository
+}
+
+func (s *Storage) Leaderboard() leaderboard_domain.LeaderboardRepository {
+	if s.leaderboardRepository != nil {
+		return s.leaderboardRepository
+	}
+
+	s.leaderboardRepository = &Leaderboard{
+		DB: s.DB,
+	}
+
+	return s.leaderboardRepository
+}
+
+func (s *Storage) Season() season_domain.SeasonRepository {
+	if s.seasonRepository != nil {
+		return s.seasonRepository
+	}
+
+	s.seasonRepository = &Season{
+		DB: s.DB,
+	}
+
+	return s.seasonRepository
+}
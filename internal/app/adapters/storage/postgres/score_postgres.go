@@ -0,0 +1,298 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+)
+
+type Score struct {
+	DB *sql.DB
+}
+
+// ledgerEntry is a score change to apply, amount moves the lifetime score and
+// spendable the points that can be spent, the optional fields tell where it came from.
+// Rewards and grants add the same amount to both. Spending and transfers only
+// move spendable. An admin deduction takes its full amount from the lifetime
+// score but spendable only down to zero, points already spent stay spent
+type ledgerEntry struct {
+	userID         uuid.UUID
+	amount         int64
+	spendable      int64
+	reason         string
+	taskID         *int64
+	questID        *int64
+	referralUserID *uuid.UUID
+	periodKey      *string
+	actorID        *uuid.UUID
+	comment        string
+	redemptionID   *int64
+	transferID     *int64
+	seasonID       *int64
+}
+
+// applyScore appends e to the ledger and moves the user's score by its amount
+// in the same transaction, it returns the new score
+func applyScore(ctx context.Context, tx *sql.Tx, e *ledgerEntry) (int64, error) {
+	entry, err := appendLedger(ctx, tx, e)
+	if err != nil {
+		return 0, err
+	}
+
+	return entry.Balance, nil
+}
+
+// appendLedger is applyScore returning the whole new entry, a zero amount
+// only reports the current score
+func appendLedger(ctx context.Context, tx *sql.Tx, e *ledgerEntry) (*score_domain.LedgerEntry, error) {
+	entry := &score_domain.LedgerEntry{
+		Amount:          e.amount,
+		SpendableAmount: e.spendable,
+		RedemptionID:    e.redemptionID,
+		TransferID:      e.transferID,
+		SeasonID:        e.seasonID,
+		Reason:          e.reason,
+		TaskID:          e.taskID,
+		QuestID:         e.questID,
+		ReferralUserID:  e.referralUserID,
+		PeriodKey:       e.periodKey,
+		ActorID:         e.actorID,
+		Comment:         e.comment,
+	}
+
+	// zero rewards leave no trace in the ledger
+	if e.amount == 0 && e.spendable == 0 {
+		if err := tx.QueryRowContext(ctx,
+			"SELECT score, spendable FROM users_scoreboard WHERE user_id = $1",
+			e.userID,
+		).Scan(&entry.Balance, &entry.SpendableBalance); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, score_domain.ErrUserNotFound
+			}
+			return nil, fmt.Errorf("failed to select users_scoreboard: %w", err)
+		}
+		return entry, nil
+	}
+
+	if err := tx.QueryRowContext(ctx,
+		`WITH s AS (
+			UPDATE users_scoreboard SET score = score + $2, spendable = spendable + $3
+			WHERE user_id = $1
+			RETURNING score, spendable
+		)
+		INSERT INTO score_ledger (
+			user_id, amount, balance, spendable_amount, spendable_balance, reason,
+			task_id, quest_id, referral_user_id, period_key, actor_id, comment, redemption_id, transfer_id, season_id
+		)
+		SELECT $1, $2, s.score, $3, s.spendable, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13 FROM s
+		RETURNING entry_id, balance, spendable_balance, created_at`,
+		e.userID, e.amount, e.spendable, e.reason,
+		e.taskID, e.questID, e.referralUserID, e.periodKey, e.actorID, e.comment, e.redemptionID, e.transferID, e.seasonID,
+	).Scan(&entry.EntryID, &entry.Balance, &entry.SpendableBalance, &entry.CreatedAt); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, score_domain.ErrUserNotFound
+		case isCheckViolation(err) && violatedConstraint(err) == "users_scoreboard_spendable_check":
+			return nil, score_domain.ErrInsufficientBalance
+		case isCheckViolation(err):
+			return nil, score_domain.ErrNegativeScore
+		}
+		return nil, fmt.Errorf("failed to update users_scoreboard: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (s *Score) History(ctx context.Context, userID uuid.UUID, cursor int64, limit int) ([]*score_domain.LedgerEntry, error) {
+	rows, err := s.DB.QueryContext(ctx,
+		`SELECT l.entry_id, l.amount, l.balance, l.spendable_amount, l.spendable_balance, l.redemption_id,
+			l.transfer_id, CASE WHEN tr.sender_id = l.user_id THEN tr.recipient_id ELSE tr.sender_id END, l.season_id,
+			l.reason, l.task_id, t.slug, l.quest_id, l.referral_user_id, l.period_key, l.actor_id, l.comment, l.created_at
+		FROM score_ledger l
+		LEFT JOIN tasks t USING (task_id)
+		LEFT JOIN transfers tr USING (transfer_id)
+		WHERE l.user_id = $1 AND ($2 = 0 OR l.entry_id < $2)
+		ORDER BY l.entry_id DESC
+		LIMIT $3`,
+		userID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*score_domain.LedgerEntry{}
+	for rows.Next() {
+		e := &score_domain.LedgerEntry{}
+		var referral, actor, counterparty uuid.NullUUID
+		if err := rows.Scan(
+			&e.EntryID, &e.Amount, &e.Balance, &e.SpendableAmount, &e.SpendableBalance, &e.RedemptionID,
+			&e.TransferID, &counterparty, &e.SeasonID,
+			&e.Reason, &e.TaskID, &e.Task, &e.QuestID, &referral, &e.PeriodKey, &actor, &e.Comment, &e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if referral.Valid {
+			e.ReferralUserID = &referral.UUID
+		}
+		if actor.Valid {
+			e.ActorID = &actor.UUID
+		}
+		if counterparty.Valid {
+			e.CounterpartyID = &counterparty.UUID
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (s *Score) Adjust(ctx context.Context, a *score_domain.Adjustment) (e *score_domain.LedgerEntry, err error) {
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start 'adjust score' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	spendable := a.Amount
+	if spendable < 0 {
+		var balance int64
+		if err = tx.QueryRowContext(ctx,
+			"SELECT spendable FROM users_scoreboard WHERE user_id = $1 FOR UPDATE",
+			a.UserID,
+		).Scan(&balance); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, score_domain.ErrUserNotFound
+			}
+			return nil, fmt.Errorf("failed to select users_scoreboard: %w", err)
+		}
+		spendable = max(spendable, -balance)
+	}
+
+	e, err = appendLedger(ctx, tx, &ledgerEntry{
+		userID:    a.UserID,
+		amount:    a.Amount,
+		spendable: spendable,
+		reason:    score_domain.ReasonAdjustment,
+		actorID:   &a.ActorID,
+		comment:   a.Comment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (s *Score) Transfer(ctx context.Context, t *score_domain.Transfer, limits score_domain.TransferLimits) (err error) {
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("failed to start 'transfer' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	// both balances are locked in user_id order so that two opposite
+	// transfers cannot deadlock
+	first, second := t.SenderID, t.RecipientID
+	if bytes.Compare(first[:], second[:]) > 0 {
+		first, second = second, first
+	}
+	for _, id := range []uuid.UUID{first, second} {
+		if _, err = tx.ExecContext(ctx,
+			"SELECT 1 FROM users_scoreboard WHERE user_id = $1 FOR UPDATE",
+			id,
+		); err != nil {
+			return fmt.Errorf("failed to lock users_scoreboard: %w", err)
+		}
+	}
+
+	var (
+		createdAt time.Time
+		sent      int64
+	)
+
+	// the sender's lock serializes its transfers, so the day total cannot race
+	if err = tx.QueryRowContext(ctx,
+		`SELECT u.created_at, COALESCE((
+			SELECT SUM(amount) FROM transfers
+			WHERE sender_id = u.user_id
+			AND created_at >= date_trunc('day', NOW() AT TIME ZONE u.timezone) AT TIME ZONE u.timezone
+		), 0)
+		FROM users u WHERE u.user_id = $1`,
+		t.SenderID,
+	).Scan(&createdAt, &sent); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return score_domain.ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get sender: %w", err)
+	}
+
+	if time.Since(createdAt) < limits.MinAccountAge {
+		return score_domain.ErrAccountTooNew
+	}
+
+	if limits.DailyLimit > 0 && sent+t.Amount > limits.DailyLimit {
+		return score_domain.ErrDailyLimit
+	}
+
+	if err = tx.QueryRowContext(ctx,
+		`INSERT INTO transfers (sender_id, recipient_id, amount, comment)
+		VALUES ($1, $2, $3, $4)
+		RETURNING transfer_id, created_at`,
+		t.SenderID, t.RecipientID, t.Amount, t.Comment,
+	).Scan(&t.TransferID, &t.CreatedAt); err != nil {
+		if isForeignKeyViolation(err) {
+			return score_domain.ErrUserNotFound
+		}
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	if _, err = applyScore(ctx, tx, &ledgerEntry{
+		userID:     t.SenderID,
+		spendable:  -t.Amount,
+		reason:     score_domain.ReasonTransferOut,
+		comment:    t.Comment,
+		transferID: &t.TransferID,
+	}); err != nil {
+		return err
+	}
+
+	if _, err = applyScore(ctx, tx, &ledgerEntry{
+		userID:     t.RecipientID,
+		spendable:  t.Amount,
+		reason:     score_domain.ReasonTransferIn,
+		comment:    t.Comment,
+		transferID: &t.TransferID,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,373 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/achievement_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/season_domain"
+)
+
+type Season struct {
+	DB *sql.DB
+}
+
+func (s *Season) CreateSeason(ctx context.Context, season *season_domain.Season) (err error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start 'create season' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	// concurrent creations must not both pass the overlap check
+	if _, err = tx.ExecContext(ctx, "LOCK TABLE seasons IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return fmt.Errorf("failed to lock seasons: %w", err)
+	}
+
+	var overlap bool
+	if err = tx.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM seasons WHERE starts_at < $2 AND ends_at > $1)",
+		season.StartsAt, season.EndsAt,
+	).Scan(&overlap); err != nil {
+		return fmt.Errorf("failed to check seasons: %w", err)
+	}
+	if overlap {
+		return season_domain.ErrOverlap
+	}
+
+	if err = tx.QueryRowContext(ctx,
+		`INSERT INTO seasons (slug, title, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING season_id, created_at`,
+		season.Slug, season.Title, season.StartsAt, season.EndsAt,
+	).Scan(&season.SeasonID, &season.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return season_domain.ErrSlugTaken
+		}
+		return fmt.Errorf("failed to create season: %w", err)
+	}
+
+	for _, r := range season.Rewards {
+		if _, err = tx.ExecContext(ctx,
+			"INSERT INTO season_rewards (season_id, up_to_rank, points) VALUES ($1, $2, $3)",
+			season.SeasonID, r.UpToRank, r.Points,
+		); err != nil {
+			return fmt.Errorf("failed to create season reward: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Season) ListSeasons(ctx context.Context) ([]*season_domain.Season, error) {
+	rows, err := s.DB.QueryContext(ctx,
+		`SELECT season_id, slug, title, starts_at, ends_at, closed_at, created_at
+		FROM seasons ORDER BY starts_at DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seasons: %w", err)
+	}
+	defer rows.Close()
+
+	seasons := []*season_domain.Season{}
+	for rows.Next() {
+		season := &season_domain.Season{}
+		if err := rows.Scan(&season.SeasonID, &season.Slug, &season.Title, &season.StartsAt, &season.EndsAt, &season.ClosedAt, &season.CreatedAt); err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, season)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	if err := s.loadRewards(ctx, seasons); err != nil {
+		return nil, err
+	}
+
+	return seasons, nil
+}
+
+func (s *Season) Season(ctx context.Context, seasonID int64) (*season_domain.Season, error) {
+	season := &season_domain.Season{}
+
+	if err := s.DB.QueryRowContext(ctx,
+		`SELECT season_id, slug, title, starts_at, ends_at, closed_at, created_at
+		FROM seasons WHERE season_id = $1`,
+		seasonID,
+	).Scan(&season.SeasonID, &season.Slug, &season.Title, &season.StartsAt, &season.EndsAt, &season.ClosedAt, &season.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, season_domain.ErrSeasonNotFound
+		}
+		return nil, fmt.Errorf("failed to get season: %w", err)
+	}
+
+	if err := s.loadRewards(ctx, []*season_domain.Season{season}); err != nil {
+		return nil, err
+	}
+
+	return season, nil
+}
+
+// loadRewards fills the reward tiers of seasons
+func (s *Season) loadRewards(ctx context.Context, seasons []*season_domain.Season) error {
+	ids := make([]int64, 0, len(seasons))
+	byID := make(map[int64]*season_domain.Season, len(seasons))
+	for _, season := range seasons {
+		season.Rewards = []*season_domain.Reward{}
+		ids = append(ids, season.SeasonID)
+		byID[season.SeasonID] = season
+	}
+
+	rows, err := s.DB.QueryContext(ctx,
+		`SELECT season_id, up_to_rank, points FROM season_rewards
+		WHERE season_id = ANY($1::int[])
+		ORDER BY season_id, up_to_rank`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get season rewards: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		r := &season_domain.Reward{}
+		if err := rows.Scan(&id, &r.UpToRank, &r.Points); err != nil {
+			return err
+		}
+		byID[id].Rewards = append(byID[id].Rewards, r)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Season) Standings(ctx context.Context, seasonID int64, after *leaderboard_domain.Cursor, limit int) ([]*leaderboard_domain.Entry, error) {
+	var (
+		score  sql.NullInt64
+		userID uuid.NullUUID
+	)
+	if after != nil {
+		score = sql.NullInt64{Int64: after.Score, Valid: true}
+		userID = uuid.NullUUID{UUID: after.UserID, Valid: true}
+	}
+
+	// archived ranks follow the (score DESC, user_id) order of the cursor
+	rows, err := s.DB.QueryContext(ctx,
+		`SELECT user_id, score, rank FROM season_standings
+		WHERE season_id = $1 AND ($2::BIGINT IS NULL OR score < $2 OR (score = $2 AND user_id > $3))
+		ORDER BY rank
+		LIMIT $4`,
+		seasonID, score, userID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get season standings: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*leaderboard_domain.Entry{}
+	for rows.Next() {
+		e := &leaderboard_domain.Entry{}
+		if err := rows.Scan(&e.UserID, &e.Score, &e.Rank); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (s *Season) UserStandings(ctx context.Context, userID uuid.UUID) ([]*season_domain.Standing, error) {
+	rows, err := s.DB.QueryContext(ctx,
+		`SELECT s.season_id, s.slug, s.title, s.starts_at, s.ends_at, st.rank, st.score, st.reward
+		FROM season_standings st
+		JOIN seasons s USING (season_id)
+		WHERE st.user_id = $1
+		ORDER BY s.starts_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user seasons: %w", err)
+	}
+	defer rows.Close()
+
+	standings := []*season_domain.Standing{}
+	for rows.Next() {
+		st := &season_domain.Standing{}
+		if err := rows.Scan(&st.SeasonID, &st.Slug, &st.Title, &st.StartsAt, &st.EndsAt, &st.Rank, &st.Score, &st.Reward); err != nil {
+			return nil, err
+		}
+		standings = append(standings, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return standings, nil
+}
+
+func (s *Season) CloseSeason(ctx context.Context, now time.Time) (c *season_domain.Closing, err error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start 'close season' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	season := &season_domain.Season{}
+	if err = tx.QueryRowContext(ctx,
+		`SELECT season_id, slug, title, starts_at, ends_at, created_at FROM seasons
+		WHERE closed_at IS NULL AND ends_at <= $1
+		ORDER BY ends_at, season_id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`,
+		now,
+	).Scan(&season.SeasonID, &season.Slug, &season.Title, &season.StartsAt, &season.EndsAt, &season.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get season to close: %w", err)
+	}
+
+	c = &season_domain.Closing{Season: season}
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO season_standings (season_id, user_id, rank, score)
+		SELECT $1, user_id, ROW_NUMBER() OVER (ORDER BY score DESC, user_id), score
+		FROM (
+			SELECT user_id, SUM(amount) AS score FROM score_ledger
+			WHERE created_at >= $2 AND created_at < $3 AND amount <> 0 AND reason <> $4
+			GROUP BY user_id
+			HAVING SUM(amount) > 0
+		) w`,
+		season.SeasonID, season.StartsAt, season.EndsAt, score_domain.ReasonSeason,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive season standings: %w", err)
+	}
+	if c.Standings, err = res.RowsAffected(); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx,
+		`UPDATE season_standings st SET reward = (
+			SELECT r.points FROM season_rewards r
+			WHERE r.season_id = st.season_id AND r.up_to_rank >= st.rank
+			ORDER BY r.up_to_rank
+			LIMIT 1
+		)
+		WHERE st.season_id = $1 AND st.rank <= (SELECT MAX(up_to_rank) FROM season_rewards WHERE season_id = $1)
+		RETURNING st.user_id, st.reward`,
+		season.SeasonID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set season rewards: %w", err)
+	}
+
+	c.Payouts = []*season_domain.Payout{}
+	for rows.Next() {
+		p := &season_domain.Payout{}
+		if err = rows.Scan(&p.UserID, &p.Points); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		c.Payouts = append(c.Payouts, p)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	for _, p := range c.Payouts {
+		if p.Score, err = applyScore(ctx, tx, &ledgerEntry{
+			userID:    p.UserID,
+			amount:    p.Points,
+			spendable: p.Points,
+			reason:    score_domain.ReasonSeason,
+			seasonID:  &season.SeasonID,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Badges, err = awardSeasonBadges(ctx, tx, season.SeasonID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.QueryRowContext(ctx,
+		"UPDATE seasons SET closed_at = $2 WHERE season_id = $1 RETURNING closed_at",
+		season.SeasonID, now,
+	).Scan(&season.ClosedAt); err != nil {
+		return nil, fmt.Errorf("failed to close season: %w", err)
+	}
+
+	return c, nil
+}
+
+// awardSeasonBadges unlocks the season_rank achievements the final standings
+// reached, it returns only the new ones
+func awardSeasonBadges(ctx context.Context, tx *sql.Tx, seasonID int64) ([]*season_domain.Badge, error) {
+	rows, err := tx.QueryContext(ctx,
+		`WITH unlocked AS (
+			INSERT INTO users_achievements (user_id, achievement_id)
+			SELECT st.user_id, a.achievement_id
+			FROM season_standings st
+			JOIN achievements a ON a.active AND a.metric = $2 AND st.rank <= a.threshold
+			WHERE st.season_id = $1
+			ON CONFLICT (user_id, achievement_id) DO NOTHING
+			RETURNING user_id, achievement_id
+		)
+		SELECT u.user_id, a.achievement_id, a.slug, a.title
+		FROM unlocked u
+		JOIN achievements a USING (achievement_id)`,
+		seasonID, achievement_domain.MetricSeasonRank,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to award season achievements: %w", err)
+	}
+	defer rows.Close()
+
+	badges := []*season_domain.Badge{}
+	for rows.Next() {
+		b := &season_domain.Badge{}
+		if err := rows.Scan(&b.UserID, &b.AchievementID, &b.Slug, &b.Title); err != nil {
+			return nil, err
+		}
+		badges = append(badges, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return badges, nil
+}
@@ -0,0 +1,326 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/store_domain"
+)
+
+type Store struct {
+	DB *sql.DB
+}
+
+func (s *Store) CreateItem(ctx context.Context, item *store_domain.Item) error {
+	if err := s.DB.QueryRowContext(ctx,
+		`INSERT INTO reward_items (slug, title, description, cost, stock, per_user_limit, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING item_id, created_at`,
+		item.Slug, item.Title, item.Description, item.Cost, item.Stock, item.PerUserLimit, item.Active,
+	).Scan(&item.ItemID, &item.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return store_domain.ErrSlugTaken
+		}
+		return fmt.Errorf("failed to create reward item: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) GetItem(ctx context.Context, itemID int64) (*store_domain.Item, error) {
+	item := &store_domain.Item{}
+
+	if err := s.DB.QueryRowContext(ctx,
+		"SELECT item_id, slug, title, description, cost, stock, per_user_limit, active, created_at FROM reward_items WHERE item_id = $1",
+		itemID,
+	).Scan(&item.ItemID, &item.Slug, &item.Title, &item.Description, &item.Cost, &item.Stock, &item.PerUserLimit, &item.Active, &item.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, store_domain.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get reward item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (s *Store) UpdateItem(ctx context.Context, item *store_domain.Item) error {
+	res, err := s.DB.ExecContext(ctx,
+		`UPDATE reward_items SET slug = $1, title = $2, description = $3, cost = $4, stock = $5, per_user_limit = $6, active = $7
+		WHERE item_id = $8`,
+		item.Slug, item.Title, item.Description, item.Cost, item.Stock, item.PerUserLimit, item.Active, item.ItemID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return store_domain.ErrSlugTaken
+		}
+		return fmt.Errorf("failed to update reward item: %w", err)
+	}
+
+	r, err := res.RowsAffected()
+	if err == nil {
+		if r == 0 {
+			return store_domain.ErrItemNotFound
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) ListItems(ctx context.Context, activeOnly bool) ([]*store_domain.Item, error) {
+	rows, err := s.DB.QueryContext(ctx,
+		`SELECT item_id, slug, title, description, cost, stock, per_user_limit, active, created_at
+		FROM reward_items
+		WHERE active OR NOT $1
+		ORDER BY item_id`,
+		activeOnly,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reward items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*store_domain.Item{}
+	for rows.Next() {
+		item := &store_domain.Item{}
+		if err := rows.Scan(&item.ItemID, &item.Slug, &item.Title, &item.Description, &item.Cost, &item.Stock, &item.PerUserLimit, &item.Active, &item.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *Store) Redeem(ctx context.Context, userID uuid.UUID, itemID int64) (rd *store_domain.Redemption, err error) {
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start 'redeem' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var (
+		item   = &store_domain.Item{}
+		active bool
+	)
+
+	// the item row lock serializes redemptions of the item, which keeps
+	// both the stock and the per-user limit exact
+	if err = tx.QueryRowContext(ctx,
+		"SELECT item_id, slug, cost, stock, per_user_limit, active FROM reward_items WHERE item_id = $1 FOR UPDATE",
+		itemID,
+	).Scan(&item.ItemID, &item.Slug, &item.Cost, &item.Stock, &item.PerUserLimit, &active); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, store_domain.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to select reward item: %w", err)
+	}
+
+	if !active {
+		return nil, store_domain.ErrItemUnavailable
+	}
+
+	if item.Stock != nil && *item.Stock == 0 {
+		return nil, store_domain.ErrOutOfStock
+	}
+
+	if item.PerUserLimit != nil {
+		var redeemed int64
+		if err = tx.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM redemptions WHERE user_id = $1 AND item_id = $2 AND status <> 'refunded'",
+			userID, itemID,
+		).Scan(&redeemed); err != nil {
+			return nil, fmt.Errorf("failed to count redemptions: %w", err)
+		}
+
+		if redeemed >= *item.PerUserLimit {
+			return nil, store_domain.ErrLimitReached
+		}
+	}
+
+	if item.Stock != nil {
+		if _, err = tx.ExecContext(ctx,
+			"UPDATE reward_items SET stock = stock - 1 WHERE item_id = $1",
+			itemID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to update stock: %w", err)
+		}
+	}
+
+	rd = &store_domain.Redemption{
+		UserID: userID,
+		ItemID: itemID,
+		Item:   item.Slug,
+		Cost:   item.Cost,
+		Status: store_domain.StatusPending,
+	}
+
+	if err = tx.QueryRowContext(ctx,
+		`INSERT INTO redemptions (user_id, item_id, cost)
+		VALUES ($1, $2, $3)
+		RETURNING redemption_id, created_at, updated_at`,
+		userID, itemID, item.Cost,
+	).Scan(&rd.RedemptionID, &rd.CreatedAt, &rd.UpdatedAt); err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, score_domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to create redemption: %w", err)
+	}
+
+	if _, err = applyScore(ctx, tx, &ledgerEntry{
+		userID:       userID,
+		spendable:    -item.Cost,
+		reason:       score_domain.ReasonRedemption,
+		redemptionID: &rd.RedemptionID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return rd, nil
+}
+
+func (s *Store) UserRedemptions(ctx context.Context, userID uuid.UUID) ([]*store_domain.Redemption, error) {
+	return s.redemptions(ctx,
+		`SELECT r.redemption_id, r.user_id, r.item_id, i.slug, r.cost, r.status, r.created_at, r.updated_at
+		FROM redemptions r
+		JOIN reward_items i USING (item_id)
+		WHERE r.user_id = $1
+		ORDER BY r.redemption_id DESC`,
+		userID,
+	)
+}
+
+func (s *Store) ListRedemptions(ctx context.Context, status string) ([]*store_domain.Redemption, error) {
+	return s.redemptions(ctx,
+		`SELECT r.redemption_id, r.user_id, r.item_id, i.slug, r.cost, r.status, r.created_at, r.updated_at
+		FROM redemptions r
+		JOIN reward_items i USING (item_id)
+		WHERE r.status = $1
+		ORDER BY r.redemption_id`,
+		status,
+	)
+}
+
+func (s *Store) redemptions(ctx context.Context, query string, args ...interface{}) ([]*store_domain.Redemption, error) {
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list redemptions: %w", err)
+	}
+	defer rows.Close()
+
+	list := []*store_domain.Redemption{}
+	for rows.Next() {
+		rd := &store_domain.Redemption{}
+		if err := rows.Scan(&rd.RedemptionID, &rd.UserID, &rd.ItemID, &rd.Item, &rd.Cost, &rd.Status, &rd.CreatedAt, &rd.UpdatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, rd)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return list, nil
+}
+
+func (s *Store) Fulfill(ctx context.Context, redemptionID int64) (*store_domain.Redemption, error) {
+	rd := &store_domain.Redemption{RedemptionID: redemptionID}
+
+	if err := s.DB.QueryRowContext(ctx,
+		`UPDATE redemptions r SET status = 'fulfilled', updated_at = NOW()
+		FROM reward_items i
+		WHERE r.item_id = i.item_id AND r.redemption_id = $1 AND r.status = 'pending'
+		RETURNING r.user_id, r.item_id, i.slug, r.cost, r.status, r.created_at, r.updated_at`,
+		redemptionID,
+	).Scan(&rd.UserID, &rd.ItemID, &rd.Item, &rd.Cost, &rd.Status, &rd.CreatedAt, &rd.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, s.transitionError(ctx, redemptionID)
+		}
+		return nil, fmt.Errorf("failed to fulfill redemption: %w", err)
+	}
+
+	return rd, nil
+}
+
+func (s *Store) Refund(ctx context.Context, redemptionID int64) (rd *store_domain.Redemption, err error) {
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start 'refund' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	rd = &store_domain.Redemption{RedemptionID: redemptionID}
+
+	if err = tx.QueryRowContext(ctx,
+		`UPDATE redemptions r SET status = 'refunded', updated_at = NOW()
+		FROM reward_items i
+		WHERE r.item_id = i.item_id AND r.redemption_id = $1 AND r.status = 'pending'
+		RETURNING r.user_id, r.item_id, i.slug, r.cost, r.status, r.created_at, r.updated_at`,
+		redemptionID,
+	).Scan(&rd.UserID, &rd.ItemID, &rd.Item, &rd.Cost, &rd.Status, &rd.CreatedAt, &rd.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, s.transitionError(ctx, redemptionID)
+		}
+		return nil, fmt.Errorf("failed to refund redemption: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx,
+		"UPDATE reward_items SET stock = stock + 1 WHERE item_id = $1 AND stock IS NOT NULL",
+		rd.ItemID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update stock: %w", err)
+	}
+
+	if _, err = applyScore(ctx, tx, &ledgerEntry{
+		userID:       rd.UserID,
+		spendable:    rd.Cost,
+		reason:       score_domain.ReasonRefund,
+		redemptionID: &rd.RedemptionID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return rd, nil
+}
+
+// transitionError tells a missing redemption from one in the wrong status
+func (s *Store) transitionError(ctx context.Context, redemptionID int64) error {
+	var exists bool
+
+	if err := s.DB.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM redemptions WHERE redemption_id = $1)",
+		redemptionID,
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to select redemption: %w", err)
+	}
+
+	if !exists {
+		return store_domain.ErrRedemptionNotFound
+	}
+
+	return store_domain.ErrInvalidTransition
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+)
+
+type Streak struct {
+	DB *sql.DB
+}
+
+func (s *Streak) Freeze(ctx context.Context, userID uuid.UUID) (st *streak_domain.Streak, err error) {
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start 'freeze streak' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	st, today, err := lockStreak(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = st.CheckFreeze(today); err != nil {
+		return nil, err
+	}
+
+	covered := st.LastDay.AddDate(0, 0, 1)
+	st.LastDay = &covered
+	st.Freezes--
+
+	if err = saveStreak(ctx, tx, userID, st); err != nil {
+		return nil, err
+	}
+
+	return st, nil
+}
+
+// recordStreak counts day towards the user's streak and awards the
+// milestone bonus it reaches, it runs in the completion's transaction so
+// the day is that of the completion and commits or fails with it
+func recordStreak(ctx context.Context, tx *sql.Tx, userID uuid.UUID, day time.Time, rules streak_domain.Rules) (*streak_domain.Update, error) {
+	st, _, err := lockStreak(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	upd := &streak_domain.Update{Streak: st}
+
+	if st.LastDay != nil && !st.LastDay.Before(day) {
+		return upd, nil
+	}
+
+	next := st.Next(day, rules)
+	upd.Streak = &next
+	upd.Counted = true
+
+	if err = saveStreak(ctx, tx, userID, &next); err != nil {
+		return nil, err
+	}
+
+	upd.Bonus = rules.Milestones[next.Current]
+	if upd.Bonus > 0 {
+		periodKey := day.Format(time.DateOnly)
+		if upd.Score, err = applyScore(ctx, tx, &ledgerEntry{
+			userID:    userID,
+			amount:    upd.Bonus,
+			spendable: upd.Bonus,
+			reason:    score_domain.ReasonStreak,
+			periodKey: &periodKey,
+			comment:   fmt.Sprintf("%d-day streak", next.Current),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return upd, nil
+}
+
+// lockStreak locks the user's streak row, creating it on first use, and
+// returns it together with today's date in the user's timezone
+func lockStreak(ctx context.Context, tx *sql.Tx, userID uuid.UUID) (*streak_domain.Streak, time.Time, error) {
+	var (
+		st    = &streak_domain.Streak{}
+		today time.Time
+	)
+
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO users_streaks (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING",
+		userID,
+	); err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, today, score_domain.ErrUserNotFound
+		}
+		return nil, today, fmt.Errorf("failed to create streak: %w", err)
+	}
+
+	if err := tx.QueryRowContext(ctx,
+		`SELECT s.current, s.longest, s.freezes, s.last_day, (NOW() AT TIME ZONE u.timezone)::date
+		FROM users_streaks s
+		JOIN users u USING (user_id)
+		WHERE s.user_id = $1
+		FOR UPDATE OF s`,
+		userID,
+	).Scan(&st.Current, &st.Longest, &st.Freezes, &st.LastDay, &today); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, today, score_domain.ErrUserNotFound
+		}
+		return nil, today, fmt.Errorf("failed to get streak: %w", err)
+	}
+
+	return st, today, nil
+}
+
+func saveStreak(ctx context.Context, tx *sql.Tx, userID uuid.UUID, st *streak_domain.Streak) error {
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users_streaks SET current = $1, longest = $2, freezes = $3, last_day = $4, updated_at = NOW()
+		WHERE user_id = $5`,
+		st.Current, st.Longest, st.Freezes, st.LastDay, userID,
+	); err != nil {
+		return fmt.Errorf("failed to update streak: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,349 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
+)
+
+type Task struct {
+	DB *sql.DB
+}
+
+func (t *Task) CreateTask(ctx context.Context, task *task_domain.Task) (err error) {
+	tx, err := t.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start 'create task' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if err = tx.QueryRowContext(ctx,
+		`INSERT INTO tasks (slug, title, description, reward, active, verifier, recurrence, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING task_id, created_at`,
+		task.Slug, task.Title, task.Description, task.Reward, task.Active, task.Verifier, task.Recurrence, task.StartsAt, task.EndsAt,
+	).Scan(&task.TaskID, &task.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return task_domain.ErrSlugTaken
+		}
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+
+	// nothing requires a new task yet, so its prerequisites cannot form a cycle
+	return setPrerequisites(ctx, tx, task.TaskID, task.Prerequisites)
+}
+
+func (t *Task) GetTask(ctx context.Context, taskID int64) (*task_domain.Task, error) {
+	task := &task_domain.Task{}
+
+	if err := t.DB.QueryRowContext(ctx,
+		`SELECT task_id, slug, title, description, reward, active, verifier, recurrence, starts_at, ends_at, created_at,
+			ARRAY(SELECT prerequisite_id FROM task_prerequisites p WHERE p.task_id = tasks.task_id ORDER BY prerequisite_id)
+		FROM tasks WHERE task_id = $1`,
+		taskID,
+	).Scan(&task.TaskID, &task.Slug, &task.Title, &task.Description, &task.Reward, &task.Active, &task.Verifier, &task.Recurrence, &task.StartsAt, &task.EndsAt, &task.CreatedAt, pq.Array(&task.Prerequisites)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task_domain.ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return task, nil
+}
+
+func (t *Task) UpdateTask(ctx context.Context, task *task_domain.Task) (err error) {
+	tx, err := t.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start 'update task' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE tasks SET slug = $1, title = $2, description = $3, reward = $4, active = $5, verifier = $6, recurrence = $7,
+			ends_at = $8, starts_at = $9,
+			-- a rescheduled campaign is launched again when its new start comes
+			launched_at = CASE WHEN starts_at IS DISTINCT FROM $9 THEN NULL ELSE launched_at END
+		WHERE task_id = $10`,
+		task.Slug, task.Title, task.Description, task.Reward, task.Active, task.Verifier, task.Recurrence, task.EndsAt, task.StartsAt, task.TaskID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return task_domain.ErrSlugTaken
+		}
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	r, err := res.RowsAffected()
+	if err == nil {
+		if r == 0 {
+			return task_domain.ErrTaskNotFound
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM task_prerequisites WHERE task_id = $1", task.TaskID); err != nil {
+		return fmt.Errorf("failed to clear prerequisites: %w", err)
+	}
+
+	if err = setPrerequisites(ctx, tx, task.TaskID, task.Prerequisites); err != nil {
+		return err
+	}
+
+	return checkRequirementCycle(ctx, tx, []int64{task.TaskID})
+}
+
+func (t *Task) DeactivateTask(ctx context.Context, taskID int64) error {
+	res, err := t.DB.ExecContext(ctx,
+		"UPDATE tasks SET active = false WHERE task_id = $1",
+		taskID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate task: %w", err)
+	}
+
+	r, err := res.RowsAffected()
+	if err == nil {
+		if r == 0 {
+			return task_domain.ErrTaskNotFound
+		}
+	}
+
+	return nil
+}
+
+func (t *Task) ListTasks(ctx context.Context) ([]*task_domain.Task, error) {
+	rows, err := t.DB.QueryContext(ctx,
+		`SELECT task_id, slug, title, description, reward, active, verifier, recurrence, starts_at, ends_at, created_at,
+			ARRAY(SELECT prerequisite_id FROM task_prerequisites p WHERE p.task_id = tasks.task_id ORDER BY prerequisite_id)
+		FROM tasks ORDER BY task_id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []*task_domain.Task{}
+	for rows.Next() {
+		task := &task_domain.Task{}
+		if err := rows.Scan(&task.TaskID, &task.Slug, &task.Title, &task.Description, &task.Reward, &task.Active, &task.Verifier, &task.Recurrence, &task.StartsAt, &task.EndsAt, &task.CreatedAt, pq.Array(&task.Prerequisites)); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func (t *Task) AssignTask(ctx context.Context, taskID int64, userIDs []uuid.UUID) (int64, error) {
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+
+	res, err := t.DB.ExecContext(ctx,
+		`INSERT INTO users_tasks (user_id, task_id)
+		SELECT user_id, $1 FROM users WHERE user_id = ANY($2::uuid[])
+		ON CONFLICT (user_id, task_id) DO NOTHING`,
+		taskID, pq.Array(ids),
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, task_domain.ErrTaskNotFound
+		}
+		return 0, fmt.Errorf("failed to assign task: %w", err)
+	}
+
+	return res.RowsAffected()
+}
+
+func (t *Task) AssignTaskToAll(ctx context.Context, taskID int64) (int64, error) {
+	res, err := t.DB.ExecContext(ctx,
+		`INSERT INTO users_tasks (user_id, task_id)
+		SELECT user_id, $1 FROM users
+		ON CONFLICT (user_id, task_id) DO NOTHING`,
+		taskID,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, task_domain.ErrTaskNotFound
+		}
+		return 0, fmt.Errorf("failed to assign task: %w", err)
+	}
+
+	return res.RowsAffected()
+}
+
+func (t *Task) LaunchCampaigns(ctx context.Context, now time.Time) (n int64, err error) {
+	tx, err := t.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start 'launch campaigns' transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx,
+		`UPDATE tasks SET active = true, launched_at = $1
+		WHERE launched_at IS NULL AND starts_at <= $1 AND (ends_at IS NULL OR ends_at > $1)
+		RETURNING task_id`,
+		now,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to launch campaigns: %w", err)
+	}
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if _, err = tx.ExecContext(ctx,
+		`INSERT INTO users_tasks (user_id, task_id)
+		SELECT u.user_id, t.task_id FROM users u CROSS JOIN tasks t
+		WHERE t.task_id = ANY($1::int[])
+		ON CONFLICT (user_id, task_id) DO NOTHING`,
+		pq.Array(ids),
+	); err != nil {
+		return 0, fmt.Errorf("failed to assign campaign tasks: %w", err)
+	}
+
+	return int64(len(ids)), nil
+}
+
+func (t *Task) RetireCampaigns(ctx context.Context, now time.Time) (int64, error) {
+	res, err := t.DB.ExecContext(ctx,
+		"UPDATE tasks SET active = false WHERE active AND ends_at <= $1",
+		now,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retire campaigns: %w", err)
+	}
+
+	return res.RowsAffected()
+}
+
+func setPrerequisites(ctx context.Context, tx *sql.Tx, taskID int64, prerequisites []int64) error {
+	if len(prerequisites) == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO task_prerequisites (task_id, prerequisite_id)
+		SELECT $1, unnest($2::int[])`,
+		taskID, pq.Array(prerequisites),
+	); err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("prerequisite: %w", task_domain.ErrTaskNotFound)
+		}
+		return fmt.Errorf("failed to set prerequisites: %w", err)
+	}
+
+	return nil
+}
+
+// checkRequirementCycle fails when one of taskIDs ends up requiring itself
+// through prerequisites or quest steps
+func checkRequirementCycle(ctx context.Context, tx *sql.Tx, taskIDs []int64) error {
+	var cycle bool
+
+	if err := tx.QueryRowContext(ctx,
+		`WITH RECURSIVE reach (task_id, required_id) AS (
+			SELECT task_id, required_id FROM task_requirements WHERE task_id = ANY($1::int[])
+			UNION
+			SELECT r.task_id, tr.required_id FROM reach r JOIN task_requirements tr ON tr.task_id = r.required_id
+		)
+		SELECT EXISTS (SELECT 1 FROM reach WHERE task_id = required_id)`,
+		pq.Array(taskIDs),
+	).Scan(&cycle); err != nil {
+		return fmt.Errorf("failed to check task requirements: %w", err)
+	}
+
+	if cycle {
+		return task_domain.ErrCycle
+	}
+
+	return nil
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// missingRequirements returns the slugs of tasks the user still has to
+// complete before taskID unlocks
+func missingRequirements(ctx context.Context, q queryer, userID uuid.UUID, taskID int64) ([]string, error) {
+	rows, err := q.QueryContext(ctx,
+		`SELECT t.slug
+		FROM task_requirements r
+		JOIN tasks t ON t.task_id = r.required_id
+		WHERE r.task_id = $2 AND NOT EXISTS (
+			SELECT 1 FROM users_tasks_completions c WHERE c.user_id = $1 AND c.task_id = r.required_id
+		)
+		ORDER BY t.task_id`,
+		userID, taskID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task requirements: %w", err)
+	}
+	defer rows.Close()
+
+	missing := []string{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, err
+		}
+		missing = append(missing, slug)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return missing, nil
+}
@@ -5,14 +5,19 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
 	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
 )
 
 var (
-	refReward = 100
-	usrReward = 50
+	refReward int64 = 100
+	usrReward int64 = 50
 )
 
 type User struct {
@@ -22,10 +27,19 @@ type User struct {
 func (u *User) UserStatus(ctx context.Context, userID uuid.UUID) (*user_domain.User, error) {
 	usr := &user_domain.User{}
 
+	// a streak is still alive when yesterday is covered, or the day before
+	// and a freeze is left to cover yesterday
 	if err := u.DB.QueryRowContext(ctx,
-		"SELECT score FROM users_scoreboard WHERE user_id = $1",
+		`SELECT sb.score, sb.spendable,
+			COALESCE(CASE WHEN st.last_day >= d.today - 1 OR (st.last_day = d.today - 2 AND st.freezes > 0) THEN st.current END, 0),
+			COALESCE(st.longest, 0), COALESCE(st.freezes, 0)
+		FROM users_scoreboard sb
+		JOIN users usr USING (user_id)
+		LEFT JOIN users_streaks st USING (user_id)
+		CROSS JOIN LATERAL (SELECT (NOW() AT TIME ZONE usr.timezone)::date AS today) d
+		WHERE sb.user_id = $1`,
 		userID,
-	).Scan(&usr.Score); err != nil {
+	).Scan(&usr.Score, &usr.Spendable, &usr.Streak, &usr.LongestStreak, &usr.StreakFreezes); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("user not found")
 		} else {
@@ -38,38 +52,94 @@ func (u *User) UserStatus(ctx context.Context, userID uuid.UUID) (*user_domain.U
 	return usr, nil
 }
 
-func (u *User) Leaderboard(ctx context.Context) (map[int]map[string]interface{}, error) {
-	users := make(map[int]map[string]interface{})
-
+func (u *User) UserTasks(ctx context.Context, userID uuid.UUID, state string) ([]*user_domain.UserTask, error) {
 	rows, err := u.DB.QueryContext(ctx,
-		"SELECT user_id, score FROM users_scoreboard ORDER BY score DESC LIMIT 10",
+		`SELECT t.task_id, t.slug, t.title, t.description, t.reward, t.recurrence, t.starts_at, t.ends_at, usr.timezone,
+			ut.referrer_id, ut.status, ut.status_reason, lc.period_key, lc.completed_at,
+			EXISTS (
+				SELECT 1 FROM task_requirements r
+				WHERE r.task_id = ut.task_id AND NOT EXISTS (
+					SELECT 1 FROM users_tasks_completions c WHERE c.user_id = ut.user_id AND c.task_id = r.required_id
+				)
+			) AS locked
+		FROM users_tasks ut
+		JOIN tasks t USING (task_id)
+		JOIN users usr USING (user_id)
+		LEFT JOIN LATERAL (
+			SELECT c.period_key, c.completed_at
+			FROM users_tasks_completions c
+			WHERE c.user_id = ut.user_id AND c.task_id = ut.task_id
+			ORDER BY c.completed_at DESC
+			LIMIT 1
+		) lc ON true
+		WHERE ut.user_id = $1
+			AND (t.active OR t.ends_at <= NOW())
+			AND ($2::text IN ('all', 'completed', 'incomplete') OR ut.status = $2::text)
+		ORDER BY t.task_id`,
+		userID, state,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+		return nil, fmt.Errorf("failed to get user tasks: %w", err)
 	}
 	defer rows.Close()
 
-	i := 1
+	now := time.Now()
+	tasks := []*user_domain.UserTask{}
 	for rows.Next() {
-		var usr user_domain.User
-		err = rows.Scan(&usr.UserID, &usr.Score)
-		if err != nil {
+		t := &user_domain.UserTask{}
+		var (
+			timezone    string
+			referrerID  uuid.NullUUID
+			lastPeriod  sql.NullString
+			completedAt sql.NullTime
+		)
+		if err := rows.Scan(
+			&t.TaskID, &t.Slug, &t.Title, &t.Description, &t.Reward, &t.Recurrence, &t.StartsAt, &t.EndsAt, &timezone,
+			&referrerID, &t.Status, &t.StatusReason, &lastPeriod, &completedAt, &t.Locked,
+		); err != nil {
 			return nil, err
 		}
-		users[i] = map[string]interface{}{"user_id": usr.UserID, "score": usr.Score}
-		i++
+		if referrerID.Valid {
+			t.ReferrerID = &referrerID.UUID
+		}
+		if completedAt.Valid {
+			t.CompletedAt = &completedAt.Time
+		}
+		t.Expired = t.EndsAt != nil && !now.Before(*t.EndsAt)
+
+		// a recurring task is complete only for the period it was done in
+		if lastPeriod.Valid {
+			loc, err := time.LoadLocation(timezone)
+			if err != nil {
+				return nil, fmt.Errorf("invalid user timezone: %w", err)
+			}
+
+			period, err := task_domain.PeriodKey(t.Recurrence, now, loc)
+			if err != nil {
+				return nil, err
+			}
+
+			t.Complete = lastPeriod.String == period
+		}
+
+		if (state == user_domain.TaskStateCompleted && !t.Complete) ||
+			(state == user_domain.TaskStateIncomplete && t.Complete) {
+			continue
+		}
+
+		tasks = append(tasks, t)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
-	return users, nil
+	return tasks, nil
 }
 
-func (u *User) CompleteUserTask(ctx context.Context, userID uuid.UUID, task string) error {
-	tx, err := u.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+func (u *User) CompleteUserTask(ctx context.Context, userID uuid.UUID, task string, idempotencyKey string, streaks streak_domain.Rules) (c *user_domain.TaskCompletion, err error) {
+	tx, err := u.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
-		return fmt.Errorf("failed to start 'complete task' transaction: %w", err)
+		return nil, fmt.Errorf("failed to start 'complete task' transaction: %w", err)
 	}
 	defer func() {
 		if p := recover(); p != nil {
@@ -82,35 +152,264 @@ func (u *User) CompleteUserTask(ctx context.Context, userID uuid.UUID, task stri
 		}
 	}()
 
-	reward := 0
+	var (
+		taskID      int64
+		active      bool
+		recurrence  string
+		timezone    string
+		complete    bool
+		status      string
+		startsAt    *time.Time
+		endsAt      *time.Time
+		completedAt sql.NullTime
+	)
+	c = &user_domain.TaskCompletion{Task: task, Status: verification_domain.StatusVerified}
+
+	// lock the user's task row so concurrent completions are serialized, and
+	// the user row so quest progress is checked against the other completions
 	if err = tx.QueryRowContext(ctx,
-		"UPDATE users_tasks SET complete = true WHERE user_id = $1 AND task = $2 RETURNING reward",
+		`SELECT ut.task_id, t.active, t.reward, t.recurrence, usr.timezone, ut.complete, ut.status, t.starts_at, t.ends_at
+		FROM users_tasks ut
+		JOIN tasks t USING (task_id)
+		JOIN users usr USING (user_id)
+		WHERE ut.user_id = $1 AND t.slug = $2
+		FOR UPDATE OF ut FOR NO KEY UPDATE OF usr`,
 		userID, task,
-	).Scan(&reward); err != nil {
+	).Scan(&taskID, &active, &c.Reward, &recurrence, &timezone, &complete, &status, &startsAt, &endsAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("task not found")
+			return nil, task_domain.ErrTaskNotFound
 		}
-		return fmt.Errorf("failed to update users_task: %w", err)
+		return nil, fmt.Errorf("failed to select users_task: %w", err)
 	}
 
-	row, err := tx.ExecContext(ctx,
-		"UPDATE users_scoreboard SET score = score + $1 WHERE user_id = $2",
-		reward, userID,
+	if idempotencyKey != "" {
+		var storedTaskID int64
+		err = tx.QueryRowContext(ctx,
+			`SELECT task_id, period_key, awarded, reward, completed_at
+			FROM users_tasks_idempotency
+			WHERE user_id = $1 AND idempotency_key = $2`,
+			userID, idempotencyKey,
+		).Scan(&storedTaskID, &c.Period, &c.Awarded, &c.Reward, &completedAt)
+		switch {
+		case err == nil:
+			if storedTaskID != taskID {
+				return nil, user_domain.ErrIdempotencyKeyReused
+			}
+			if completedAt.Valid {
+				c.CompletedAt = &completedAt.Time
+			}
+			return c, nil
+		case !errors.Is(err, sql.ErrNoRows):
+			return nil, fmt.Errorf("failed to select idempotency key: %w", err)
+		}
+	}
+
+	now := time.Now()
+
+	if task_domain.Withdrawn(active, endsAt, now) {
+		return nil, task_domain.ErrInactive
+	}
+
+	// a submission that went pending during the campaign can still be approved after it ends
+	if !complete && status != verification_domain.StatusPending {
+		if err = task_domain.CheckWindow(startsAt, endsAt, now); err != nil {
+			return nil, err
+		}
+
+		var missing []string
+		missing, err = missingRequirements(ctx, tx, userID, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if len(missing) > 0 {
+			return nil, task_domain.LockedError(missing)
+		}
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user timezone: %w", err)
+	}
+
+	c.Period, err = task_domain.PeriodKey(recurrence, now, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO users_tasks_completions (user_id, task_id, period_key, reward)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, task_id, period_key) DO NOTHING
+		RETURNING completed_at`,
+		userID, taskID, c.Period, c.Reward,
+	).Scan(&completedAt)
+	switch {
+	case err == nil:
+		c.Awarded = true
+	case errors.Is(err, sql.ErrNoRows):
+		// already completed in this period, report the original completion
+		if err = tx.QueryRowContext(ctx,
+			"SELECT reward, completed_at FROM users_tasks_completions WHERE user_id = $1 AND task_id = $2 AND period_key = $3",
+			userID, taskID, c.Period,
+		).Scan(&c.Reward, &completedAt); err != nil {
+			return nil, fmt.Errorf("failed to select users_tasks_completions: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to insert users_tasks_completions: %w", err)
+	}
+
+	if c.Awarded {
+		if _, err = tx.ExecContext(ctx,
+			`UPDATE users_tasks
+			SET complete = $1, completed_at = $2, status = 'verified', status_reason = '', status_updated_at = NOW()
+			WHERE user_id = $3 AND task_id = $4`,
+			recurrence == task_domain.RecurrenceOnce, completedAt, userID, taskID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to update users_task: %w", err)
+		}
+
+		if c.Score, err = applyScore(ctx, tx, &ledgerEntry{
+			userID:    userID,
+			amount:    c.Reward,
+			spendable: c.Reward,
+			reason:    score_domain.ReasonTask,
+			taskID:    &taskID,
+			periodKey: &c.Period,
+		}); err != nil {
+			return nil, err
+		}
+
+		c.Quests, err = completeQuests(ctx, tx, userID, taskID)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range c.Quests {
+			c.Score += q.Bonus
+		}
+
+		// the day is the completion's in the user's timezone, not the one
+		// the request happens to be handled on
+		y, m, d := completedAt.Time.In(loc).Date()
+		c.Streak, err = recordStreak(ctx, tx, userID, time.Date(y, m, d, 0, 0, 0, 0, time.UTC), streaks)
+		if err != nil {
+			return nil, err
+		}
+		c.Score += c.Streak.Bonus
+	}
+
+	c.CompletedAt = &completedAt.Time
+
+	if idempotencyKey != "" {
+		if _, err = tx.ExecContext(ctx,
+			`INSERT INTO users_tasks_idempotency (user_id, idempotency_key, task_id, period_key, awarded, reward, completed_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			userID, idempotencyKey, taskID, c.Period, c.Awarded, c.Reward, completedAt,
+		); err != nil {
+			if isUniqueViolation(err) {
+				return nil, user_domain.ErrIdempotencyKeyReused
+			}
+			return nil, fmt.Errorf("failed to save idempotency key: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+// completeQuests records every active quest containing taskID that the user
+// has now finished and awards their bonuses
+func completeQuests(ctx context.Context, tx *sql.Tx, userID uuid.UUID, taskID int64) ([]*user_domain.QuestBonus, error) {
+	rows, err := tx.QueryContext(ctx,
+		`WITH done AS (
+			INSERT INTO users_quests (user_id, quest_id, bonus)
+			SELECT $1, q.quest_id, q.bonus
+			FROM quests q
+			JOIN quest_steps s ON s.quest_id = q.quest_id AND s.task_id = $2
+			WHERE q.active AND NOT EXISTS (
+				SELECT 1 FROM quest_steps qs
+				WHERE qs.quest_id = q.quest_id AND NOT EXISTS (
+					SELECT 1 FROM users_tasks_completions c WHERE c.user_id = $1 AND c.task_id = qs.task_id
+				)
+			)
+			ON CONFLICT (user_id, quest_id) DO NOTHING
+			RETURNING quest_id, bonus
+		)
+		SELECT d.quest_id, q.slug, d.bonus FROM done d JOIN quests q USING (quest_id) ORDER BY d.quest_id`,
+		userID, taskID,
 	)
-	r, err := row.RowsAffected()
-	if err == nil {
-		if r == 0 {
-			return fmt.Errorf("no rows updated")
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete quests: %w", err)
+	}
+
+	quests := []*user_domain.QuestBonus{}
+	for rows.Next() {
+		q := &user_domain.QuestBonus{}
+		if err := rows.Scan(&q.QuestID, &q.Slug, &q.Bonus); err != nil {
+			rows.Close()
+			return nil, err
 		}
+		quests = append(quests, q)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
-	return nil
+	// the rows have to be drained before the transaction runs another statement
+	for _, q := range quests {
+		if _, err := applyScore(ctx, tx, &ledgerEntry{
+			userID:    userID,
+			amount:    q.Bonus,
+			spendable: q.Bonus,
+			reason:    score_domain.ReasonQuest,
+			questID:   &q.QuestID,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return quests, nil
+}
+
+// TaskSlug falls back to the title only when no task has the slug, titles
+// are not unique, so a title shared by several tasks is rejected
+func (u *User) TaskSlug(ctx context.Context, task string) (string, error) {
+	rows, err := u.DB.QueryContext(ctx,
+		`SELECT slug FROM tasks WHERE slug = $1
+		UNION ALL
+		SELECT slug FROM tasks WHERE title = $1 AND NOT EXISTS (SELECT 1 FROM tasks WHERE slug = $1)`,
+		task,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to find task: %w", err)
+	}
+	defer rows.Close()
+
+	slugs := []string{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return "", err
+		}
+		slugs = append(slugs, slug)
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("rows error: %w", err)
+	}
+
+	switch len(slugs) {
+	case 0:
+		return task, nil
+	case 1:
+		return slugs[0], nil
+	default:
+		return "", fmt.Errorf("several tasks are titled %q, use the slug", task)
+	}
 }
 
-func (u *User) Referrer(ctx context.Context, userID uuid.UUID, referrerID uuid.UUID, task string) error {
+func (u *User) Referrer(ctx context.Context, userID uuid.UUID, referrerID uuid.UUID, task string) (referral *user_domain.Referral, err error) {
 	tx, err := u.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
 	if err != nil {
-		return fmt.Errorf("failed to start 'refferer' transaction: %w", err)
+		return nil, fmt.Errorf("failed to start 'refferer' transaction: %w", err)
 	}
 	defer func() {
 		if p := recover(); p != nil {
@@ -123,66 +422,179 @@ func (u *User) Referrer(ctx context.Context, userID uuid.UUID, referrerID uuid.U
 		}
 	}()
 
-	var ref sql.NullString
+	var (
+		taskID int64
+		ref    sql.NullString
+	)
 
 	if err = tx.QueryRowContext(ctx,
-		"SELECT referrer_id FROM users_tasks WHERE user_id = $1 AND task = $2",
+		"SELECT ut.task_id, ut.referrer_id FROM users_tasks ut JOIN tasks t USING (task_id) WHERE ut.user_id = $1 AND t.slug = $2",
 		userID, task,
-	).Scan(&ref); err != nil {
+	).Scan(&taskID, &ref); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("user not found")
+			return nil, fmt.Errorf("user not found")
 		}
-		return fmt.Errorf("failed to select referrer_id by user_id: %w", err)
+		return nil, fmt.Errorf("failed to select referrer_id by user_id: %w", err)
 	}
 
 	if ref.Valid && ref.String != "" {
-		return fmt.Errorf("cannot use refer")
+		return nil, fmt.Errorf("cannot use refer")
 	}
 
 	row, err := tx.ExecContext(ctx,
-		"UPDATE users_tasks SET referrer_id = $1 WHERE user_id = $2 AND task = $3",
+		`UPDATE users_tasks ut SET referrer_id = $1
+		FROM tasks t
+		WHERE ut.task_id = t.task_id AND ut.user_id = $2 AND t.slug = $3`,
 		referrerID, userID, task,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to update referrer_id by user_id")
+		return nil, fmt.Errorf("failed to update referrer_id by user_id: %w", err)
 	}
 
 	r, err := row.RowsAffected()
 	if err == nil {
 		if r == 0 {
-			return fmt.Errorf("no row updated")
+			return nil, fmt.Errorf("no row updated")
 		}
 	}
 
-	row, err = tx.ExecContext(ctx,
-		"UPDATE users_scoreboard SET score = score + $1 WHERE user_id = $2",
-		refReward, referrerID,
+	referral = &user_domain.Referral{Reward: usrReward, ReferrerReward: refReward}
+
+	if referral.ReferrerScore, err = applyScore(ctx, tx, &ledgerEntry{
+		userID:         referrerID,
+		amount:         refReward,
+		spendable:      refReward,
+		reason:         score_domain.ReasonReferrer,
+		taskID:         &taskID,
+		referralUserID: &userID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if referral.Score, err = applyScore(ctx, tx, &ledgerEntry{
+		userID:         userID,
+		amount:         usrReward,
+		spendable:      usrReward,
+		reason:         score_domain.ReasonReferral,
+		taskID:         &taskID,
+		referralUserID: &referrerID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return referral, nil
+}
+
+func (u *User) LinkTelegram(ctx context.Context, userID uuid.UUID, telegramUserID int64) error {
+	res, err := u.DB.ExecContext(ctx,
+		"UPDATE users SET telegram_user_id = $1 WHERE user_id = $2",
+		telegramUserID, userID,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to update users_scoreboard: %w", err)
+		if isUniqueViolation(err) {
+			return user_domain.ErrTelegramLinked
+		}
+		return fmt.Errorf("failed to link telegram account: %w", err)
 	}
 
-	r, err = row.RowsAffected()
+	r, err := res.RowsAffected()
 	if err == nil {
 		if r == 0 {
-			return fmt.Errorf("no row updated")
+			return fmt.Errorf("user not found")
 		}
 	}
 
-	row, err = tx.ExecContext(ctx,
-		"UPDATE users_scoreboard SET score = score + $1 WHERE user_id = $2",
-		usrReward, userID,
+	return nil
+}
+
+func (u *User) TelegramUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var id sql.NullInt64
+
+	if err := u.DB.QueryRowContext(ctx,
+		"SELECT telegram_user_id FROM users WHERE user_id = $1",
+		userID,
+	).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("user not found")
+		}
+		return 0, err
+	}
+
+	return id.Int64, nil
+}
+
+func (u *User) SetTimezone(ctx context.Context, userID uuid.UUID, timezone string) error {
+	res, err := u.DB.ExecContext(ctx,
+		"UPDATE users SET timezone = $1 WHERE user_id = $2",
+		timezone, userID,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to update users_scoreboard: %w", err)
+		return fmt.Errorf("failed to set timezone: %w", err)
 	}
 
-	r, err = row.RowsAffected()
+	r, err := res.RowsAffected()
 	if err == nil {
 		if r == 0 {
-			return fmt.Errorf("no row updated")
+			return fmt.Errorf("user not found")
 		}
 	}
 
 	return nil
 }
+
+func (u *User) MissingRequirements(ctx context.Context, userID uuid.UUID, taskID int64) ([]string, error) {
+	return missingRequirements(ctx, u.DB, userID, taskID)
+}
+
+func (u *User) UserQuests(ctx context.Context, userID uuid.UUID) ([]*user_domain.UserQuest, error) {
+	rows, err := u.DB.QueryContext(ctx,
+		`SELECT q.quest_id, q.slug, q.title, q.description, q.bonus, uq.completed_at,
+			t.task_id, t.slug, t.title,
+			EXISTS (SELECT 1 FROM users_tasks_completions c WHERE c.user_id = $1 AND c.task_id = s.task_id)
+		FROM quests q
+		JOIN quest_steps s USING (quest_id)
+		JOIN tasks t ON t.task_id = s.task_id
+		LEFT JOIN users_quests uq ON uq.quest_id = q.quest_id AND uq.user_id = $1
+		WHERE q.active OR uq.user_id IS NOT NULL
+		ORDER BY q.quest_id, s.position`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user quests: %w", err)
+	}
+	defer rows.Close()
+
+	quests := []*user_domain.UserQuest{}
+	var q *user_domain.UserQuest
+	for rows.Next() {
+		var (
+			questID     int64
+			completedAt sql.NullTime
+		)
+		cur := &user_domain.UserQuest{}
+		step := &user_domain.QuestStep{}
+		if err := rows.Scan(
+			&questID, &cur.Slug, &cur.Title, &cur.Description, &cur.Bonus, &completedAt,
+			&step.TaskID, &step.Slug, &step.Title, &step.Complete,
+		); err != nil {
+			return nil, err
+		}
+
+		if q == nil || q.QuestID != questID {
+			q = cur
+			q.QuestID = questID
+			q.Steps = []*user_domain.QuestStep{}
+			if completedAt.Valid {
+				q.Complete = true
+				q.CompletedAt = &completedAt.Time
+			}
+			quests = append(quests, q)
+		}
+		q.Steps = append(q.Steps, step)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return quests, nil
+}
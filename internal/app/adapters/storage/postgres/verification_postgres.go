@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+)
+
+type Verification struct {
+	DB *sql.DB
+}
+
+func (v *Verification) UserTaskStatus(ctx context.Context, userID uuid.UUID, task string) (*verification_domain.UserTaskStatus, error) {
+	s := &verification_domain.UserTaskStatus{UserID: userID}
+
+	var updatedAt sql.NullTime
+	if err := v.DB.QueryRowContext(ctx,
+		`SELECT ut.task_id, t.slug, t.reward, t.verifier, ut.status, ut.status_reason, ut.complete, ut.status_updated_at,
+			t.starts_at, t.ends_at, t.active
+		FROM users_tasks ut
+		JOIN tasks t USING (task_id)
+		WHERE ut.user_id = $1 AND t.slug = $2`,
+		userID, task,
+	).Scan(&s.TaskID, &s.Task, &s.Reward, &s.Verifier, &s.Status, &s.Reason, &s.Complete, &updatedAt, &s.StartsAt, &s.EndsAt, &s.Active); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task_domain.ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to get task status: %w", err)
+	}
+
+	if updatedAt.Valid {
+		s.UpdatedAt = &updatedAt.Time
+	}
+
+	return s, nil
+}
+
+func (v *Verification) SetStatus(ctx context.Context, userID uuid.UUID, taskID int64, status string, reason string) error {
+	res, err := v.DB.ExecContext(ctx,
+		`UPDATE users_tasks SET status = $1, status_reason = $2, status_updated_at = NOW()
+		WHERE user_id = $3 AND task_id = $4 AND NOT complete`,
+		status, reason, userID, taskID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update task status: %w", err)
+	}
+
+	r, err := res.RowsAffected()
+	if err == nil {
+		if r == 0 {
+			return verification_domain.ErrNotPending
+		}
+	}
+
+	return nil
+}
+
+func (v *Verification) UseNonce(ctx context.Context, nonce string, keep time.Duration) error {
+	if _, err := v.DB.ExecContext(ctx,
+		"DELETE FROM verification_callback_nonces WHERE received_at < NOW() - make_interval(secs => $1)",
+		keep.Seconds(),
+	); err != nil {
+		return fmt.Errorf("failed to prune callback nonces: %w", err)
+	}
+
+	res, err := v.DB.ExecContext(ctx,
+		"INSERT INTO verification_callback_nonces (nonce) VALUES ($1) ON CONFLICT (nonce) DO NOTHING",
+		nonce,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save callback nonce: %w", err)
+	}
+
+	r, err := res.RowsAffected()
+	if err == nil {
+		if r == 0 {
+			return verification_domain.ErrNonceUsed
+		}
+	}
+
+	return nil
+}
+
+func (v *Verification) ListByStatus(ctx context.Context, status string) ([]*verification_domain.UserTaskStatus, error) {
+	rows, err := v.DB.QueryContext(ctx,
+		`SELECT ut.user_id, ut.task_id, t.slug, t.reward, t.verifier, ut.status, ut.status_reason, ut.complete, ut.status_updated_at
+		FROM users_tasks ut
+		JOIN tasks t USING (task_id)
+		WHERE ut.status = $1
+		ORDER BY ut.status_updated_at`,
+		status,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list verifications: %w", err)
+	}
+	defer rows.Close()
+
+	list := []*verification_domain.UserTaskStatus{}
+	for rows.Next() {
+		s := &verification_domain.UserTaskStatus{}
+		var updatedAt sql.NullTime
+		if err := rows.Scan(&s.UserID, &s.TaskID, &s.Task, &s.Reward, &s.Verifier, &s.Status, &s.Reason, &s.Complete, &updatedAt); err != nil {
+			return nil, err
+		}
+		if updatedAt.Valid {
+			s.UpdatedAt = &updatedAt.Time
+		}
+		list = append(list, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return list, nil
+}
@@ -1,11 +1,29 @@
 package storage
 
 import (
+	"github.com/vo1dFl0w/users-service/internal/app/domain/achievement_domain"
 	"github.com/vo1dFl0w/users-service/internal/app/domain/auth_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/quest_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/season_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/store_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
 	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
 )
 
 type Storage interface {
 	Auth() auth_domain.AuthRepository
 	User() user_domain.UserRepository
-}
\ No newline at end of file
+	Task() task_domain.TaskRepository
+	Verification() verification_domain.VerificationRepository
+	Quest() quest_domain.QuestRepository
+	Score() score_domain.ScoreRepository
+	Store() store_domain.StoreRepository
+	Achievement() achievement_domain.AchievementRepository
+	Streak() streak_domain.StreakRepository
+	Leaderboard() leaderboard_domain.LeaderboardRepository
+	Season() season_domain.SeasonRepository
+}
@@ -0,0 +1,76 @@
+package telegram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+)
+
+// clockSkew is how far auth_date may be ahead of the local clock
+const clockSkew = time.Minute
+
+// Login verifies Telegram Login widget payloads, they are signed by
+// Telegram with a key derived from the bot token
+type Login struct {
+	secret [32]byte
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+func NewLogin(token string, maxAge time.Duration) *Login {
+	return &Login{
+		secret: sha256.Sum256([]byte(token)),
+		maxAge: maxAge,
+		now:    time.Now,
+	}
+}
+
+// Verify checks hash, the hex HMAC-SHA256 of the other fields sorted by
+// name as "key=value" lines, and that auth_date is at most maxAge old
+func (l *Login) Verify(fields map[string]string) (int64, error) {
+	hash, err := hex.DecodeString(fields["hash"])
+	if err != nil || len(hash) != sha256.Size {
+		return 0, user_domain.ErrTelegramLoginInvalid
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		if k != "hash" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, len(keys))
+	for i, k := range keys {
+		lines[i] = k + "=" + fields[k]
+	}
+
+	mac := hmac.New(sha256.New, l.secret[:])
+	mac.Write([]byte(strings.Join(lines, "\n")))
+	if !hmac.Equal(mac.Sum(nil), hash) {
+		return 0, user_domain.ErrTelegramLoginInvalid
+	}
+
+	authDate, err := strconv.ParseInt(fields["auth_date"], 10, 64)
+	if err != nil {
+		return 0, user_domain.ErrTelegramLoginInvalid
+	}
+	signedAt := time.Unix(authDate, 0)
+	if now := l.now(); now.Sub(signedAt) > l.maxAge || signedAt.Sub(now) > clockSkew {
+		return 0, user_domain.ErrTelegramLoginExpired
+	}
+
+	id, err := strconv.ParseInt(fields["id"], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, user_domain.ErrTelegramLoginInvalid
+	}
+
+	return id, nil
+}
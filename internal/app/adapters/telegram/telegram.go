@@ -0,0 +1,152 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAccountNotLinked = errors.New("telegram account is not linked")
+)
+
+// AccountResolver maps a service user to the linked Telegram user id
+type AccountResolver interface {
+	TelegramUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+type cached struct {
+	member    bool
+	expiresAt time.Time
+}
+
+// Client checks channel membership through the Bot API getChatMember method
+type Client struct {
+	baseURL  string
+	token    string
+	chatID   string
+	cacheTTL time.Duration
+	http     *http.Client
+	accounts AccountResolver
+
+	mu    sync.Mutex
+	cache map[int64]cached
+}
+
+func New(baseURL string, token string, chatID string, cacheTTL time.Duration, timeout time.Duration, accounts AccountResolver) *Client {
+	return &Client{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		token:    token,
+		chatID:   chatID,
+		cacheTTL: cacheTTL,
+		http:     &http.Client{Timeout: timeout},
+		accounts: accounts,
+		cache:    make(map[int64]cached),
+	}
+}
+
+func (c *Client) IsMember(ctx context.Context, userID uuid.UUID) (bool, error) {
+	tgUserID, err := c.accounts.TelegramUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if tgUserID == 0 {
+		return false, ErrAccountNotLinked
+	}
+
+	if member, ok := c.cached(tgUserID); ok {
+		return member, nil
+	}
+
+	member, err := c.getChatMember(ctx, tgUserID)
+	if err != nil {
+		return false, err
+	}
+
+	// only members are cached, a user who has just joined is not made to
+	// wait out the TTL
+	if member {
+		c.mu.Lock()
+		c.cache[tgUserID] = cached{member: member, expiresAt: time.Now().Add(c.cacheTTL)}
+		c.mu.Unlock()
+	}
+
+	return member, nil
+}
+
+func (c *Client) cached(tgUserID int64) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.cache[tgUserID]
+	if !ok {
+		return false, false
+	}
+
+	if time.Now().After(v.expiresAt) {
+		delete(c.cache, tgUserID)
+		return false, false
+	}
+
+	return v.member, true
+}
+
+func (c *Client) getChatMember(ctx context.Context, tgUserID int64) (bool, error) {
+	q := url.Values{}
+	q.Set("chat_id", c.chatID)
+	q.Set("user_id", strconv.FormatInt(tgUserID, 10))
+
+	endpoint := fmt.Sprintf("%s/bot%s/getChatMember?%s", c.baseURL, c.token, q.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to build telegram request: %w", err)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		// the error text contains the url with the bot token
+		return false, fmt.Errorf("telegram request failed")
+	}
+	defer resp.Body.Close()
+
+	body := struct {
+		OK          bool   `json:"ok"`
+		ErrorCode   int    `json:"error_code"`
+		Description string `json:"description"`
+		Result      struct {
+			Status   string `json:"status"`
+			IsMember bool   `json:"is_member"`
+		} `json:"result"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return false, fmt.Errorf("failed to decode telegram response: %w", err)
+	}
+
+	if !body.OK {
+		// the Bot API answers 400 when the user has never been in the chat
+		if body.ErrorCode == http.StatusBadRequest &&
+			(strings.Contains(body.Description, "user not found") || strings.Contains(body.Description, "PARTICIPANT_ID_INVALID")) {
+			return false, nil
+		}
+		return false, fmt.Errorf("telegram error %d: %s", body.ErrorCode, body.Description)
+	}
+
+	switch body.Result.Status {
+	case "creator", "administrator", "member":
+		return true, nil
+	case "restricted":
+		return body.Result.IsMember, nil
+	default:
+		return false, nil
+	}
+}
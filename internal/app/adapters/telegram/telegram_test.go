@@ -0,0 +1,248 @@
+package telegram
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+)
+
+type accounts map[uuid.UUID]int64
+
+func (a accounts) TelegramUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return a[userID], nil
+}
+
+// botAPI answers getChatMember with the reply set for the Telegram user
+// and counts the requests per user
+type botAPI struct {
+	mu      sync.Mutex
+	replies map[string]string
+	calls   map[string]int
+}
+
+func (b *botAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	user := r.URL.Query().Get("user_id")
+	b.calls[user]++
+
+	if r.URL.Path != "/bottoken/getChatMember" || r.URL.Query().Get("chat_id") != "@channel" {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"ok":false,"error_code":404,"description":"Not Found"}`))
+		return
+	}
+
+	w.Write([]byte(b.replies[user]))
+}
+
+func (b *botAPI) set(user string, reply string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.replies[user] = reply
+}
+
+func (b *botAPI) count(user string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.calls[user]
+}
+
+func TestIsMember(t *testing.T) {
+	api := &botAPI{
+		replies: map[string]string{
+			"1": `{"ok":true,"result":{"status":"member"}}`,
+			"2": `{"ok":true,"result":{"status":"left"}}`,
+			"3": `{"ok":false,"error_code":400,"description":"Bad Request: user not found"}`,
+			"4": `{"ok":true,"result":{"status":"restricted","is_member":true}}`,
+			"5": `{"ok":false,"error_code":403,"description":"Forbidden: bot is not a member of the channel chat"}`,
+		},
+		calls: make(map[string]int),
+	}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	users := accounts{}
+	ids := map[string]uuid.UUID{}
+	for i, user := range []string{"1", "2", "3", "4", "5"} {
+		ids[user] = uuid.New()
+		users[ids[user]] = int64(i + 1)
+	}
+
+	client := New(srv.URL+"/", "token", "@channel", time.Hour, time.Second, users)
+
+	tests := []struct {
+		name    string
+		user    string
+		member  bool
+		wantErr bool
+	}{
+		{name: "member", user: "1", member: true},
+		{name: "left", user: "2"},
+		{name: "never joined", user: "3"},
+		{name: "restricted member", user: "4", member: true},
+		{name: "api error", user: "5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			member, err := client.IsMember(context.Background(), ids[tt.user])
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IsMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if member != tt.member {
+				t.Errorf("IsMember() = %v, want %v", member, tt.member)
+			}
+		})
+	}
+
+	t.Run("not linked", func(t *testing.T) {
+		if _, err := client.IsMember(context.Background(), uuid.New()); !errors.Is(err, ErrAccountNotLinked) {
+			t.Errorf("IsMember() error = %v, want %v", err, ErrAccountNotLinked)
+		}
+	})
+
+	t.Run("members are cached", func(t *testing.T) {
+		api.set("1", `{"ok":true,"result":{"status":"left"}}`)
+
+		member, err := client.IsMember(context.Background(), ids["1"])
+		if err != nil || !member {
+			t.Fatalf("IsMember() = %v, %v, want the cached membership", member, err)
+		}
+		if n := api.count("1"); n != 1 {
+			t.Errorf("getChatMember called %d times, want 1", n)
+		}
+	})
+
+	t.Run("non-members are asked again", func(t *testing.T) {
+		api.set("2", `{"ok":true,"result":{"status":"member"}}`)
+
+		member, err := client.IsMember(context.Background(), ids["2"])
+		if err != nil || !member {
+			t.Fatalf("IsMember() = %v, %v, want the fresh membership", member, err)
+		}
+		if n := api.count("2"); n != 2 {
+			t.Errorf("getChatMember called %d times, want 2", n)
+		}
+	})
+
+	t.Run("expired entries are asked again", func(t *testing.T) {
+		client.mu.Lock()
+		client.cache[1] = cached{member: true, expiresAt: time.Now().Add(-time.Second)}
+		client.mu.Unlock()
+
+		member, err := client.IsMember(context.Background(), ids["1"])
+		if err != nil || member {
+			t.Fatalf("IsMember() = %v, %v, want the fresh answer", member, err)
+		}
+		if n := api.count("1"); n != 2 {
+			t.Errorf("getChatMember called %d times, want 2", n)
+		}
+	})
+}
+
+// sign returns the fields with the hash the Login widget would add
+func sign(token string, fields map[string]string) map[string]string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, len(keys))
+	for i, k := range keys {
+		lines[i] = k + "=" + fields[k]
+	}
+
+	secret := sha256.Sum256([]byte(token))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(strings.Join(lines, "\n")))
+
+	signed := map[string]string{"hash": hex.EncodeToString(mac.Sum(nil))}
+	for k, v := range fields {
+		signed[k] = v
+	}
+
+	return signed
+}
+
+func TestLoginVerify(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	login := NewLogin("token", time.Minute*10)
+	login.now = func() time.Time { return now }
+
+	fresh := strconv.FormatInt(now.Add(-time.Minute).Unix(), 10)
+
+	tampered := sign("token", map[string]string{"id": "42", "first_name": "ann", "auth_date": fresh})
+	tampered["id"] = "43"
+
+	tests := []struct {
+		name    string
+		fields  map[string]string
+		id      int64
+		wantErr error
+	}{
+		{
+			name:   "valid",
+			fields: sign("token", map[string]string{"id": "42", "first_name": "ann", "username": "ann", "auth_date": fresh}),
+			id:     42,
+		},
+		{
+			name:    "other bot",
+			fields:  sign("other", map[string]string{"id": "42", "auth_date": fresh}),
+			wantErr: user_domain.ErrTelegramLoginInvalid,
+		},
+		{
+			name:    "tampered id",
+			fields:  tampered,
+			wantErr: user_domain.ErrTelegramLoginInvalid,
+		},
+		{
+			name:    "no hash",
+			fields:  map[string]string{"id": "42", "auth_date": fresh},
+			wantErr: user_domain.ErrTelegramLoginInvalid,
+		},
+		{
+			name:    "stale",
+			fields:  sign("token", map[string]string{"id": "42", "auth_date": strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)}),
+			wantErr: user_domain.ErrTelegramLoginExpired,
+		},
+		{
+			name:    "from the future",
+			fields:  sign("token", map[string]string{"id": "42", "auth_date": strconv.FormatInt(now.Add(time.Hour).Unix(), 10)}),
+			wantErr: user_domain.ErrTelegramLoginExpired,
+		},
+		{
+			name:    "no id",
+			fields:  sign("token", map[string]string{"auth_date": fresh}),
+			wantErr: user_domain.ErrTelegramLoginInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := login.Verify(tt.fields)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if id != tt.id {
+				t.Errorf("Verify() = %d, want %d", id, tt.id)
+			}
+		})
+	}
+}
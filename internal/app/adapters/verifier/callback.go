@@ -0,0 +1,22 @@
+package verifier
+
+import (
+	"context"
+
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+)
+
+// Callback leaves the task pending until an external system reports the
+// outcome through the signed verification callback
+type Callback struct{}
+
+func NewCallback() *Callback {
+	return &Callback{}
+}
+
+func (c *Callback) Verify(ctx context.Context, req *verification_domain.Request) (*verification_domain.Result, error) {
+	return &verification_domain.Result{
+		Status: verification_domain.StatusPending,
+		Reason: "awaiting external confirmation",
+	}, nil
+}
@@ -0,0 +1,45 @@
+package verifier
+
+import (
+	"context"
+	"sync"
+
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+)
+
+// Fake answers with a preset result per task slug, it is meant for local
+// runs and tests
+type Fake struct {
+	mu      sync.Mutex
+	Default verification_domain.Result
+	Results map[string]verification_domain.Result
+	Calls   []verification_domain.Request
+}
+
+func NewFake() *Fake {
+	return &Fake{
+		Default: verification_domain.Result{Status: verification_domain.StatusVerified},
+		Results: make(map[string]verification_domain.Result),
+	}
+}
+
+func (f *Fake) Set(task string, status string, reason string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Results[task] = verification_domain.Result{Status: status, Reason: reason}
+}
+
+func (f *Fake) Verify(ctx context.Context, req *verification_domain.Request) (*verification_domain.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Calls = append(f.Calls, *req)
+
+	res, ok := f.Results[req.Task]
+	if !ok {
+		res = f.Default
+	}
+
+	return &res, nil
+}
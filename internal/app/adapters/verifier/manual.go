@@ -0,0 +1,21 @@
+package verifier
+
+import (
+	"context"
+
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+)
+
+// Manual leaves the task pending until a moderator approves or rejects it
+type Manual struct{}
+
+func NewManual() *Manual {
+	return &Manual{}
+}
+
+func (m *Manual) Verify(ctx context.Context, req *verification_domain.Request) (*verification_domain.Result, error) {
+	return &verification_domain.Result{
+		Status: verification_domain.StatusPending,
+		Reason: "awaiting moderator review",
+	}, nil
+}
@@ -0,0 +1,42 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+)
+
+// MembershipChecker is implemented by platform adapters that can tell whether
+// a user has joined a channel, group or account
+type MembershipChecker interface {
+	IsMember(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+// Membership verifies the task right away by asking the platform adapter
+type Membership struct {
+	Checker MembershipChecker
+}
+
+func NewMembership(checker MembershipChecker) *Membership {
+	return &Membership{
+		Checker: checker,
+	}
+}
+
+func (m *Membership) Verify(ctx context.Context, req *verification_domain.Request) (*verification_domain.Result, error) {
+	ok, err := m.Checker.IsMember(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check membership: %w", err)
+	}
+
+	if !ok {
+		return &verification_domain.Result{
+			Status: verification_domain.StatusRejected,
+			Reason: "membership not confirmed",
+		}, nil
+	}
+
+	return &verification_domain.Result{Status: verification_domain.StatusVerified}, nil
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -18,7 +19,123 @@ type Config struct {
 		DBname   string `yaml:"dbname"`
 		Sslmode  string `yaml:"sslmode"`
 	} `yaml:"db"`
-	Secret string `yaml:"secret"`
+	Secret        string              `yaml:"secret"`
+	Challenge     ChallengeConfig     `yaml:"challenge"`
+	Verification  VerificationConfig  `yaml:"verification"`
+	Telegram      TelegramConfig      `yaml:"telegram"`
+	Campaigns     CampaignsConfig     `yaml:"campaigns"`
+	Transfers     TransfersConfig     `yaml:"transfers"`
+	Streaks       StreaksConfig       `yaml:"streaks"`
+	Levels        LevelsConfig        `yaml:"levels"`
+	Leaderboard   LeaderboardConfig   `yaml:"leaderboard"`
+	Seasons       SeasonsConfig       `yaml:"seasons"`
+	Redis         RedisConfig         `yaml:"redis"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+}
+
+// Proof-of-work settings for anonymous endpoints
+type ChallengeConfig struct {
+	Threshold      int           `yaml:"threshold" env-default:"20"`
+	Window         time.Duration `yaml:"window" env-default:"1m"`
+	TTL            time.Duration `yaml:"ttl" env-default:"2m"`
+	BaseDifficulty int           `yaml:"base_difficulty" env-default:"16"`
+	MaxDifficulty  int           `yaml:"max_difficulty" env-default:"24"`
+}
+
+// Task verification settings
+type VerificationConfig struct {
+	// Secret external systems sign verification callbacks with,
+	// callbacks are rejected when it is empty
+	CallbackSecret string `yaml:"callback_secret"`
+	// CallbackMaxAge is how far a callback timestamp may be from now
+	CallbackMaxAge time.Duration `yaml:"callback_max_age" env-default:"5m"`
+}
+
+// Telegram Bot API settings, the telegram verifier is enabled when BotToken is set
+type TelegramConfig struct {
+	BaseURL  string        `yaml:"base_url" env-default:"https://api.telegram.org"`
+	BotToken string        `yaml:"bot_token" env:"TELEGRAM_BOT_TOKEN"`
+	ChatID   string        `yaml:"chat_id"`
+	CacheTTL time.Duration `yaml:"cache_ttl" env-default:"30s"`
+	Timeout  time.Duration `yaml:"timeout" env-default:"5s"`
+	// LoginMaxAge is how old a Login widget payload may be when linking
+	LoginMaxAge time.Duration `yaml:"login_max_age" env-default:"10m"`
+}
+
+// Campaign scheduler settings
+type CampaignsConfig struct {
+	// How often tasks are checked for campaigns to launch or retire
+	Interval time.Duration `yaml:"interval" env-default:"1m"`
+}
+
+// Point transfer limits
+type TransfersConfig struct {
+	// Points a user can send per calendar day in their timezone, 0 disables the limit
+	DailyLimit int64 `yaml:"daily_limit" env-default:"1000"`
+	// How old an account has to be before it can send points
+	MinAccountAge time.Duration `yaml:"min_account_age" env-default:"168h"`
+}
+
+// Activity streak settings
+type StreaksConfig struct {
+	// A freeze is earned every FreezeEvery days of a streak, 0 disables them
+	FreezeEvery int `yaml:"freeze_every" env-default:"7"`
+	MaxFreezes  int `yaml:"max_freezes" env-default:"2"`
+	// Bonus points by the streak length that earns them
+	Milestones map[int]int64 `yaml:"milestones" env-default:"3:50,7:150,30:1000"`
+}
+
+// Level curve settings, Curve is one of linear, exponential or table
+type LevelsConfig struct {
+	Curve string `yaml:"curve" env-default:"exponential"`
+	// Score of the second level, a linear curve adds it for every next level
+	Step int64 `yaml:"step" env-default:"100"`
+	// How much more every next level costs on an exponential curve
+	Factor float64 `yaml:"factor" env-default:"1.5"`
+	// Scores levels start at on a table curve, beginning with the second level
+	Table []int64 `yaml:"table"`
+}
+
+// Leaderboard settings
+type LeaderboardConfig struct {
+	// IANA timezone days, weeks and months of periodic leaderboards start in
+	Timezone string `yaml:"timezone" env-default:"UTC"`
+	// Board serving the all-time leaderboard, postgres, memory or redis
+	Board string `yaml:"board" env-default:"memory"`
+	// Sorted set the redis board is kept in
+	RedisKey string `yaml:"redis_key" env-default:"leaderboard"`
+	// How often the memory board is reloaded from the database, the redis
+	// board is only rebuilt by cmd/leaderboard-rebuild
+	SyncInterval time.Duration `yaml:"sync_interval" env-default:"5m"`
+	// Entries of the top sent to stream subscribers
+	StreamTop int `yaml:"stream_top" env-default:"10"`
+	// How often stream subscribers are refreshed at most
+	StreamInterval time.Duration `yaml:"stream_interval" env-default:"1s"`
+	// How often idle streams are pinged
+	StreamHeartbeat time.Duration `yaml:"stream_heartbeat" env-default:"15s"`
+}
+
+// Season settings
+type SeasonsConfig struct {
+	// How often ended seasons are checked for closing
+	Interval time.Duration `yaml:"interval" env-default:"1m"`
+}
+
+// Live notification settings
+type NotificationsConfig struct {
+	// Notifications kept in memory for clients resuming with a cursor
+	History int `yaml:"history" env-default:"1024"`
+	// Notifications a connection may have waiting before it is dropped
+	Queue int `yaml:"queue" env-default:"64"`
+	// How often connections are pinged, one that misses two pongs is closed
+	PingInterval time.Duration `yaml:"ping_interval" env-default:"30s"`
+}
+
+// Redis connection settings
+type RedisConfig struct {
+	Addr     string `yaml:"addr" env-default:"localhost:6379"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD"`
+	DB       int    `yaml:"db"`
 }
 
 // Load config from config.yaml
@@ -0,0 +1,18 @@
+package achievement_domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type AchievementRepository interface {
+	CreateAchievement(ctx context.Context, achievement *Achievement) error
+	ListAchievements(ctx context.Context) ([]*Achievement, error)
+	// Unlock stores every active achievement the user has reached and did
+	// not have yet, it returns only the new ones
+	Unlock(ctx context.Context, userID uuid.UUID) ([]*UserAchievement, error)
+	// UnlockAll does what Unlock does for every user at once
+	UnlockAll(ctx context.Context) ([]*UserAchievement, error)
+	UserAchievements(ctx context.Context, userID uuid.UUID) ([]*UserAchievement, error)
+}
@@ -0,0 +1,62 @@
+package achievement_domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	validate "github.com/go-ozzo/ozzo-validation"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSlugTaken = errors.New("achievement with this slug already exists")
+)
+
+// Metrics an achievement threshold is compared with
+const (
+	MetricScore          = "score"
+	MetricTasksCompleted = "tasks_completed"
+	MetricReferrals      = "referrals"
+	MetricStreak         = "streak"
+	// MetricSeasonRank is unlocked when a season closes with the user ranked
+	// Threshold or better
+	MetricSeasonRank = "season_rank"
+)
+
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Achievement is a badge unlocked once the user's Metric reaches Threshold
+type Achievement struct {
+	AchievementID int64     `json:"achievement_id"`
+	Slug          string    `json:"slug"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	Metric        string    `json:"metric"`
+	Threshold     int64     `json:"threshold"`
+	Active        bool      `json:"active"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// UserAchievement is an unlocked achievement, UserID is only set when the
+// achievements of several users are returned together
+type UserAchievement struct {
+	UserID        uuid.UUID `json:"-"`
+	AchievementID int64     `json:"achievement_id"`
+	Slug          string    `json:"slug"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	Metric        string    `json:"metric"`
+	Threshold     int64     `json:"threshold"`
+	UnlockedAt    time.Time `json:"unlocked_at"`
+}
+
+func (a *Achievement) ValidateAchievement() error {
+	return validate.ValidateStruct(
+		a,
+		validate.Field(&a.Slug, validate.Required, validate.Length(1, 100), validate.Match(slugRegexp)),
+		validate.Field(&a.Title, validate.Required),
+		validate.Field(&a.Metric, validate.Required, validate.In(MetricScore, MetricTasksCompleted, MetricReferrals, MetricStreak, MetricSeasonRank)),
+		validate.Field(&a.Threshold, validate.Required, validate.Min(1)),
+	)
+}
@@ -10,9 +10,15 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
 	UserID             uuid.UUID `json:"user_id"`
 	Email              string    `json:"email"`
+	Role               string    `json:"role"`
 	Password           string    `json:"password,omitempty"`
 	EncryptedPassword  string    `json:"-"`
 	RefreshToken       string    `json:"-"`
@@ -0,0 +1,13 @@
+package event_domain
+
+import "context"
+
+// Publisher hands events to the subscribers of their type, it is called
+// after the change behind the event has been committed
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Handler reacts to an event, its error is reported but never reaches the
+// publisher since the change is already done
+type Handler func(ctx context.Context, event Event) error
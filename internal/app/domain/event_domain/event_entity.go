@@ -0,0 +1,83 @@
+package event_domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TypeTaskCompleted       = "task_completed"
+	TypeReferrerSet         = "referrer_set"
+	TypeAchievementUnlocked = "achievement_unlocked"
+	TypeScoreChanged        = "score_changed"
+	TypeLevelUp             = "level_up"
+	TypeTaskReviewed        = "task_reviewed"
+	TypeReferralCredited    = "referral_credited"
+)
+
+// Event is something that happened to a user, Payload holds the details
+// of its type
+type Event struct {
+	Type       string      `json:"type"`
+	UserID     uuid.UUID   `json:"user_id"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Payload    interface{} `json:"payload"`
+}
+
+type TaskCompleted struct {
+	Task   string `json:"task"`
+	Reward int64  `json:"reward"`
+	Period string `json:"period"`
+}
+
+// ReferrerSet is published for the user who named ReferrerID, both of them
+// are rewarded
+type ReferrerSet struct {
+	ReferrerID uuid.UUID `json:"referrer_id"`
+	Task       string    `json:"task"`
+}
+
+// ReferralCredited is published for the referrer when ReferralID names them
+type ReferralCredited struct {
+	ReferralID uuid.UUID `json:"referral_id"`
+	Task       string    `json:"task"`
+	Reward     int64     `json:"reward"`
+	Score      int64     `json:"score"`
+}
+
+// TaskReviewed is published when a pending task is verified or rejected
+type TaskReviewed struct {
+	Task   string `json:"task"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+	Reward int64  `json:"reward"`
+}
+
+type AchievementUnlocked struct {
+	AchievementID int64  `json:"achievement_id"`
+	Slug          string `json:"slug"`
+	Title         string `json:"title"`
+}
+
+// ScoreChanged is published when rewards or adjustments move the lifetime score
+type ScoreChanged struct {
+	Reason   string `json:"reason"`
+	Previous int64  `json:"previous"`
+	Score    int64  `json:"score"`
+}
+
+type LevelUp struct {
+	Previous int   `json:"previous"`
+	Level    int   `json:"level"`
+	Score    int64 `json:"score"`
+}
+
+func New(eventType string, userID uuid.UUID, payload interface{}) Event {
+	return Event{
+		Type:       eventType,
+		UserID:     userID,
+		OccurredAt: time.Now(),
+		Payload:    payload,
+	}
+}
@@ -0,0 +1,36 @@
+package leaderboard_domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type LeaderboardRepository interface {
+	// Top returns up to limit entries ranked after the cursor, a nil cursor
+	// starts from the first place. A nil window ranks by lifetime score,
+	// otherwise by the points earned within it
+	Top(ctx context.Context, window *Window, after *Cursor, limit int) ([]*Entry, error)
+	// Rank returns the user's place together with the number of ranked users
+	Rank(ctx context.Context, userID uuid.UUID) (*Standing, error)
+	// Ranks does what Rank does for several users with one read, users
+	// without a place are left out
+	Ranks(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*Standing, error)
+	// Around returns the user's entry with up to window entries above and below
+	Around(ctx context.Context, userID uuid.UUID, window int) ([]*Entry, error)
+	// Scores returns the lifetime score of every user, ranks are not set
+	Scores(ctx context.Context) ([]*Entry, error)
+}
+
+// Board is a ranked copy of the lifetime leaderboard kept outside the
+// database, it serves the all-time reads and follows committed score changes
+type Board interface {
+	// Load replaces the whole board
+	Load(ctx context.Context, entries []*Entry) error
+	// Add moves the user's score by delta, a user not on the board starts at 0
+	Add(ctx context.Context, userID uuid.UUID, delta int64) error
+	Top(ctx context.Context, after *Cursor, limit int) ([]*Entry, error)
+	Rank(ctx context.Context, userID uuid.UUID) (*Standing, error)
+	Ranks(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*Standing, error)
+	Around(ctx context.Context, userID uuid.UUID, window int) ([]*Entry, error)
+}
@@ -0,0 +1,178 @@
+package leaderboard_domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrUserNotFound  = errors.New("user not found")
+	ErrInvalidPeriod = errors.New("invalid period")
+)
+
+// Boards the all-time leaderboard can be served from
+const (
+	BoardPostgres = "postgres"
+	BoardMemory   = "memory"
+	BoardRedis    = "redis"
+)
+
+const (
+	PeriodAllTime = "all-time"
+	PeriodDaily   = "daily"
+	PeriodWeekly  = "weekly"
+	PeriodMonthly = "monthly"
+	PeriodCustom  = "custom"
+)
+
+const (
+	DefaultLimit  = 10
+	MaxLimit      = 100
+	DefaultWindow = 5
+	MaxWindow     = 50
+	// longest custom period in days
+	MaxCustomDays = 366
+)
+
+// Entry is a place on the leaderboard, users are ordered by score and
+// equal scores by user_id so every user has a distinct rank
+type Entry struct {
+	Rank   int64     `json:"rank"`
+	UserID uuid.UUID `json:"user_id"`
+	Score  int64     `json:"score"`
+}
+
+// Standing is the user's entry, Percentile is the share of the other users
+// ranked below it
+type Standing struct {
+	Entry
+	Total      int64   `json:"total"`
+	Percentile float64 `json:"percentile"`
+}
+
+// SetPercentile fills Percentile from Rank and Total, a user alone on the
+// leaderboard is ahead of everyone
+func (s *Standing) SetPercentile() {
+	if s.Total <= 1 {
+		s.Percentile = 100
+		return
+	}
+
+	s.Percentile = math.Round(float64(s.Total-s.Rank)/float64(s.Total-1)*10000) / 100
+}
+
+// Cursor is the last entry of a page, the next page starts right after it
+type Cursor struct {
+	Score  int64
+	UserID uuid.UUID
+}
+
+func (e *Entry) Cursor() *Cursor {
+	return &Cursor{Score: e.Score, UserID: e.UserID}
+}
+
+func (c *Cursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.Score, 10) + ":" + c.UserID.String()))
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	score, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	c := &Cursor{}
+	if c.Score, err = strconv.ParseInt(score, 10, 64); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.UserID, err = uuid.Parse(id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+func ValidateLimit(limit int) error {
+	if limit <= 0 || limit > MaxLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	}
+
+	return nil
+}
+
+func ValidateWindow(window int) error {
+	if window <= 0 || window > MaxWindow {
+		return fmt.Errorf("window must be between 1 and %d", MaxWindow)
+	}
+
+	return nil
+}
+
+// Period selects the points a leaderboard ranks by, From and To are the
+// first and the last day of a custom period as YYYY-MM-DD
+type Period struct {
+	Name string
+	From string
+	To   string
+}
+
+// Window is the time range [From, To) points are summed over
+type Window struct {
+	From time.Time
+	To   time.Time
+}
+
+// Window returns the range of the period containing now with day boundaries
+// in loc, it is nil for the all-time leaderboard. Weeks start on Monday
+func (p Period) Window(now time.Time, loc *time.Location) (*Window, error) {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch p.Name {
+	case "", PeriodAllTime:
+		return nil, nil
+	case PeriodDaily:
+		return &Window{From: today, To: today.AddDate(0, 0, 1)}, nil
+	case PeriodWeekly:
+		from := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		return &Window{From: from, To: from.AddDate(0, 0, 7)}, nil
+	case PeriodMonthly:
+		from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+		return &Window{From: from, To: from.AddDate(0, 1, 0)}, nil
+	case PeriodCustom:
+		from, err := time.ParseInLocation(time.DateOnly, p.From, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from, expected YYYY-MM-DD")
+		}
+		to, err := time.ParseInLocation(time.DateOnly, p.To, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to, expected YYYY-MM-DD")
+		}
+		to = to.AddDate(0, 0, 1)
+
+		if !from.Before(to) {
+			return nil, fmt.Errorf("from must not be after to")
+		}
+		if from.AddDate(0, 0, MaxCustomDays).Before(to) {
+			return nil, fmt.Errorf("custom period must not be longer than %d days", MaxCustomDays)
+		}
+
+		return &Window{From: from, To: to}, nil
+	default:
+		return nil, fmt.Errorf("%w, expected one of: %s, %s, %s, %s, %s", ErrInvalidPeriod,
+			PeriodAllTime, PeriodDaily, PeriodWeekly, PeriodMonthly, PeriodCustom)
+	}
+}
@@ -0,0 +1,9 @@
+package level_domain
+
+// Curve maps a lifetime score to a level, levels start at 1 from a score of 0
+type Curve interface {
+	// Threshold returns the score the level starts at, false when the curve
+	// has no such level
+	Threshold(level int) (int64, bool)
+	Level(score int64) int
+}
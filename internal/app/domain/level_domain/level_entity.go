@@ -0,0 +1,150 @@
+package level_domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	CurveLinear      = "linear"
+	CurveExponential = "exponential"
+	CurveTable       = "table"
+)
+
+// maxLevel bounds exponential curves, whose thresholds would overflow long before
+const maxLevel = 1000
+
+// Level is where a score stands on the curve, NextThreshold is nil at the
+// last level of a table curve
+type Level struct {
+	Level         int     `json:"level"`
+	Threshold     int64   `json:"threshold"`
+	NextThreshold *int64  `json:"next_threshold"`
+	Progress      float64 `json:"progress"`
+}
+
+// NewCurve builds the curve named by kind, step is the score of the second
+// level and factor how much more each next level costs on an exponential curve
+func NewCurve(kind string, step int64, factor float64, table []int64) (Curve, error) {
+	switch kind {
+	case CurveLinear:
+		if step <= 0 {
+			return nil, fmt.Errorf("linear level curve needs a positive step")
+		}
+		return linear{step: step}, nil
+	case CurveExponential:
+		if step <= 0 || factor <= 1 {
+			return nil, fmt.Errorf("exponential level curve needs a positive step and a factor above 1")
+		}
+		return newExponential(step, factor), nil
+	case CurveTable:
+		if len(table) == 0 {
+			return nil, fmt.Errorf("table level curve needs thresholds")
+		}
+		prev := int64(0)
+		for _, t := range table {
+			if t <= prev {
+				return nil, fmt.Errorf("table level curve thresholds must be positive and increasing")
+			}
+			prev = t
+		}
+		return tableCurve{thresholds: table}, nil
+	default:
+		return nil, fmt.Errorf("invalid level curve, expected one of: %s, %s, %s", CurveLinear, CurveExponential, CurveTable)
+	}
+}
+
+// Progress places score on c
+func Progress(c Curve, score int64) Level {
+	l := Level{Level: c.Level(score)}
+	l.Threshold, _ = c.Threshold(l.Level)
+
+	if next, ok := c.Threshold(l.Level + 1); ok {
+		l.NextThreshold = &next
+		l.Progress = float64(score-l.Threshold) / float64(next-l.Threshold)
+	} else {
+		l.Progress = 1
+	}
+
+	return l
+}
+
+type linear struct {
+	step int64
+}
+
+func (c linear) Threshold(level int) (int64, bool) {
+	if level < 1 {
+		return 0, false
+	}
+	return c.step * int64(level-1), true
+}
+
+func (c linear) Level(score int64) int {
+	if score < 0 {
+		return 1
+	}
+	return int(score/c.step) + 1
+}
+
+// exponential keeps its thresholds precomputed, level n costs
+// step*factor^(n-2) more than level n-1
+type exponential struct {
+	thresholds []int64
+}
+
+func newExponential(step int64, factor float64) exponential {
+	thresholds := []int64{0}
+	cost := float64(step)
+	total := float64(0)
+	for len(thresholds) < maxLevel {
+		total += cost
+		if total >= math.MaxInt64/2 {
+			break
+		}
+		thresholds = append(thresholds, int64(math.Round(total)))
+		cost *= factor
+	}
+
+	return exponential{thresholds: thresholds}
+}
+
+func (c exponential) Threshold(level int) (int64, bool) {
+	if level < 1 || level > len(c.thresholds) {
+		return 0, false
+	}
+	return c.thresholds[level-1], true
+}
+
+func (c exponential) Level(score int64) int {
+	return levelOf(c.thresholds, score)
+}
+
+type tableCurve struct {
+	thresholds []int64
+}
+
+func (c tableCurve) Threshold(level int) (int64, bool) {
+	switch {
+	case level == 1:
+		return 0, true
+	case level < 1 || level > len(c.thresholds)+1:
+		return 0, false
+	default:
+		return c.thresholds[level-2], true
+	}
+}
+
+func (c tableCurve) Level(score int64) int {
+	return levelOf(append([]int64{0}, c.thresholds...), score)
+}
+
+// levelOf returns the number of thresholds reached by score, the first one is 0
+func levelOf(thresholds []int64, score int64) int {
+	n := sort.Search(len(thresholds), func(i int) bool { return thresholds[i] > score })
+	if n == 0 {
+		return 1
+	}
+	return n
+}
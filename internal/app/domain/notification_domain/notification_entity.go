@@ -0,0 +1,129 @@
+package notification_domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrQueueOverflow = errors.New("send queue overflow")
+)
+
+const (
+	TypeTaskReviewed     = "task_reviewed"
+	TypeReferralCredited = "referral_credited"
+	TypeBadgeUnlocked    = "badge_unlocked"
+	TypeRankChanged      = "rank_changed"
+	TypeLeaderboardTop   = "leaderboard_top"
+	TypeSubscribed       = "subscribed"
+	TypeReset            = "reset"
+	TypePong             = "pong"
+	TypeError            = "error"
+)
+
+const (
+	TopicLeaderboard = "leaderboard"
+	TopicBadges      = "badges"
+)
+
+const (
+	RequestSubscribe   = "subscribe"
+	RequestUnsubscribe = "unsubscribe"
+	RequestPing        = "ping"
+)
+
+// Notification is a message for a live connection. UserID is who it is
+// meant for, notifications of a topic go to its subscribers as well. ID is
+// set on the notifications kept for resuming and is the cursor to send back
+// after a reconnect
+type Notification struct {
+	ID         string      `json:"id,omitempty"`
+	Type       string      `json:"type"`
+	Topic      string      `json:"topic,omitempty"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data,omitempty"`
+	UserID     uuid.UUID   `json:"-"`
+}
+
+type BadgeUnlocked struct {
+	UserID        uuid.UUID `json:"user_id"`
+	AchievementID int64     `json:"achievement_id"`
+	Slug          string    `json:"slug"`
+	Title         string    `json:"title"`
+}
+
+type LeaderboardTop struct {
+	Entries []*leaderboard_domain.Entry `json:"entries"`
+}
+
+type Subscribed struct {
+	Topics []string `json:"topics"`
+}
+
+type Error struct {
+	Error string `json:"error"`
+}
+
+// Request is a message from the client
+type Request struct {
+	Type   string   `json:"type"`
+	Topics []string `json:"topics"`
+}
+
+// Cursor points at the last notification a client got. Notifications are
+// only kept in memory, Epoch tells cursors of an earlier process apart
+type Cursor struct {
+	Epoch int64
+	Seq   uint64
+}
+
+func New(notificationType string, userID uuid.UUID, data interface{}) Notification {
+	return Notification{
+		Type:       notificationType,
+		OccurredAt: time.Now(),
+		Data:       data,
+		UserID:     userID,
+	}
+}
+
+func (c Cursor) String() string {
+	return strconv.FormatInt(c.Epoch, 36) + "-" + strconv.FormatUint(c.Seq, 10)
+}
+
+func ParseCursor(s string) (*Cursor, error) {
+	epoch, seq, ok := strings.Cut(s, "-")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	e, err := strconv.ParseInt(epoch, 36, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{Epoch: e, Seq: n}, nil
+}
+
+func ValidateTopics(topics []string) error {
+	for _, t := range topics {
+		switch t {
+		case TopicLeaderboard, TopicBadges:
+		default:
+			return fmt.Errorf("invalid topic %q, expected one of: %s, %s", t, TopicLeaderboard, TopicBadges)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+package quest_domain
+
+import "context"
+
+type QuestRepository interface {
+	CreateQuest(ctx context.Context, quest *Quest) error
+	GetQuest(ctx context.Context, questID int64) (*Quest, error)
+	UpdateQuest(ctx context.Context, quest *Quest) error
+	ListQuests(ctx context.Context) ([]*Quest, error)
+}
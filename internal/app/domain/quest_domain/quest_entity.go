@@ -0,0 +1,52 @@
+package quest_domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	validate "github.com/go-ozzo/ozzo-validation"
+)
+
+var (
+	ErrQuestNotFound = errors.New("quest not found")
+	ErrSlugTaken     = errors.New("quest with this slug already exists")
+)
+
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Quest is an ordered chain of tasks, each step unlocks after the previous
+// one and Bonus is awarded once every step is done
+type Quest struct {
+	QuestID     int64     `json:"quest_id"`
+	Slug        string    `json:"slug"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Bonus       int64     `json:"bonus"`
+	Active      bool      `json:"active"`
+	Steps       []int64   `json:"steps"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (q *Quest) ValidateQuest() error {
+	return validate.ValidateStruct(
+		q,
+		validate.Field(&q.Slug, validate.Required, validate.Length(1, 100), validate.Match(slugRegexp)),
+		validate.Field(&q.Title, validate.Required),
+		validate.Field(&q.Bonus, validate.Min(0)),
+		validate.Field(&q.Steps, validate.Required, validate.By(func(value interface{}) error {
+			seen := make(map[int64]bool, len(q.Steps))
+			for _, id := range q.Steps {
+				if id <= 0 {
+					return fmt.Errorf("invalid task_id %d", id)
+				}
+				if seen[id] {
+					return fmt.Errorf("duplicate task_id %d", id)
+				}
+				seen[id] = true
+			}
+			return nil
+		})),
+	)
+}
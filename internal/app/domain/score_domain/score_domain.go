@@ -0,0 +1,18 @@
+package score_domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ScoreRepository interface {
+	// History returns up to limit ledger entries of the user older than
+	// cursor, newest first, a zero cursor starts from the latest entry
+	History(ctx context.Context, userID uuid.UUID, cursor int64, limit int) ([]*LedgerEntry, error)
+	// Adjust applies a manual score change and returns its ledger entry
+	Adjust(ctx context.Context, adjustment *Adjustment) (*LedgerEntry, error)
+	// Transfer moves spendable points between users when the sender is within
+	// limits and fills in the transfer id and time
+	Transfer(ctx context.Context, transfer *Transfer, limits TransferLimits) error
+}
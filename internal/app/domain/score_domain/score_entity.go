@@ -0,0 +1,140 @@
+package score_domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound        = errors.New("user not found")
+	ErrNegativeScore       = errors.New("score cannot become negative")
+	ErrInsufficientBalance = errors.New("not enough spendable points")
+	ErrDailyLimit          = errors.New("daily transfer limit exceeded")
+	ErrAccountTooNew       = errors.New("account is too new to transfer points")
+)
+
+const (
+	ReasonOpeningBalance = "opening_balance"
+	ReasonTask           = "task"
+	ReasonQuest          = "quest"
+	// ReasonReferral is the reward of a user who named a referrer,
+	// ReasonReferrer is the reward of the referrer
+	ReasonReferral = "referral"
+	ReasonReferrer = "referrer"
+	// ReasonAdjustment is a manual grant or deduction by an admin
+	ReasonAdjustment = "adjustment"
+	// ReasonRedemption spends points on a reward, ReasonRefund returns them
+	ReasonRedemption = "redemption"
+	ReasonRefund     = "refund"
+	// ReasonTransferOut sends spendable points to another user,
+	// ReasonTransferIn receives them
+	ReasonTransferOut = "transfer_out"
+	ReasonTransferIn  = "transfer_in"
+	// ReasonStreak is the bonus for reaching a streak milestone
+	ReasonStreak = "streak"
+	// ReasonSeason is the reward for a place in a closed season
+	ReasonSeason = "season"
+)
+
+const (
+	DefaultHistoryLimit = 50
+	MaxHistoryLimit     = 100
+)
+
+// LedgerEntry is a single score change, Balance is the lifetime score right
+// after it and SpendableBalance the spendable points
+type LedgerEntry struct {
+	EntryID          int64      `json:"entry_id"`
+	Amount           int64      `json:"amount"`
+	Balance          int64      `json:"balance"`
+	SpendableAmount  int64      `json:"spendable_amount"`
+	SpendableBalance *int64     `json:"spendable_balance"`
+	RedemptionID     *int64     `json:"redemption_id"`
+	TransferID       *int64     `json:"transfer_id"`
+	SeasonID         *int64     `json:"season_id"`
+	CounterpartyID   *uuid.UUID `json:"counterparty_id"`
+	Reason           string     `json:"reason"`
+	TaskID           *int64     `json:"task_id"`
+	Task             *string    `json:"task"`
+	QuestID          *int64     `json:"quest_id"`
+	ReferralUserID   *uuid.UUID `json:"referral_user_id"`
+	PeriodKey        *string    `json:"period_key"`
+	ActorID          *uuid.UUID `json:"actor_id"`
+	Comment          string     `json:"comment"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// Adjustment is a manual score change made by the admin ActorID
+type Adjustment struct {
+	UserID  uuid.UUID `json:"user_id"`
+	ActorID uuid.UUID `json:"actor_id"`
+	Amount  int64     `json:"amount"`
+	Comment string    `json:"comment"`
+}
+
+func (a *Adjustment) ValidateAdjustment() error {
+	switch {
+	case a.UserID == uuid.Nil:
+		return fmt.Errorf("empty user_id")
+	case a.ActorID == uuid.Nil:
+		return fmt.Errorf("empty actor_id")
+	case a.Amount == 0:
+		return fmt.Errorf("amount cannot be zero")
+	case strings.TrimSpace(a.Comment) == "":
+		return fmt.Errorf("reason is required")
+	case len(a.Comment) > 1000:
+		return fmt.Errorf("reason is too long")
+	}
+
+	return nil
+}
+
+// Transfer moves spendable points from one user to another, the lifetime
+// score of both stays the same
+type Transfer struct {
+	TransferID  int64     `json:"transfer_id"`
+	SenderID    uuid.UUID `json:"sender_id"`
+	RecipientID uuid.UUID `json:"recipient_id"`
+	Amount      int64     `json:"amount"`
+	Comment     string    `json:"comment"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TransferLimits are checked against the sender, a zero DailyLimit is unlimited
+type TransferLimits struct {
+	DailyLimit    int64
+	MinAccountAge time.Duration
+}
+
+func (t *Transfer) ValidateTransfer() error {
+	switch {
+	case t.SenderID == uuid.Nil:
+		return fmt.Errorf("empty user_id")
+	case t.RecipientID == uuid.Nil:
+		return fmt.Errorf("empty recipient_id")
+	case t.SenderID == t.RecipientID:
+		return fmt.Errorf("recipient_id cannot be the same as user_id")
+	case t.Amount <= 0:
+		return fmt.Errorf("amount must be positive")
+	case len(t.Comment) > 200:
+		return fmt.Errorf("comment is too long")
+	}
+
+	return nil
+}
+
+func ValidateHistoryPage(cursor int64, limit int) error {
+	if cursor < 0 {
+		return fmt.Errorf("invalid cursor")
+	}
+
+	if limit <= 0 || limit > MaxHistoryLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxHistoryLimit)
+	}
+
+	return nil
+}
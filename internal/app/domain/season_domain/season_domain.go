@@ -0,0 +1,22 @@
+package season_domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+)
+
+type SeasonRepository interface {
+	CreateSeason(ctx context.Context, season *Season) error
+	ListSeasons(ctx context.Context) ([]*Season, error)
+	Season(ctx context.Context, seasonID int64) (*Season, error)
+	// Standings returns up to limit archived entries of a closed season
+	// ranked after the cursor, a nil cursor starts from the first place
+	Standings(ctx context.Context, seasonID int64, after *leaderboard_domain.Cursor, limit int) ([]*leaderboard_domain.Entry, error)
+	UserStandings(ctx context.Context, userID uuid.UUID) ([]*Standing, error)
+	// CloseSeason archives the standings of the earliest season that ended
+	// by now and pays its rewards, it returns nil when no season is due
+	CloseSeason(ctx context.Context, now time.Time) (*Closing, error)
+}
@@ -0,0 +1,109 @@
+package season_domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	validate "github.com/go-ozzo/ozzo-validation"
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+)
+
+var (
+	ErrSeasonNotFound = errors.New("season not found")
+	ErrSlugTaken      = errors.New("season with this slug already exists")
+	ErrOverlap        = errors.New("season overlaps another season")
+)
+
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Season ranks users by the points they earn between StartsAt and EndsAt,
+// every season starts from zero while the lifetime score keeps growing.
+// ClosedAt is set once the final standings are archived
+type Season struct {
+	SeasonID  int64      `json:"season_id"`
+	Slug      string     `json:"slug"`
+	Title     string     `json:"title"`
+	StartsAt  time.Time  `json:"starts_at"`
+	EndsAt    time.Time  `json:"ends_at"`
+	Rewards   []*Reward  `json:"rewards"`
+	ClosedAt  *time.Time `json:"closed_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Reward is paid on close to every user ranked UpToRank or better who is
+// not covered by a tier with a smaller UpToRank
+type Reward struct {
+	UpToRank int64 `json:"up_to_rank"`
+	Points   int64 `json:"points"`
+}
+
+// Standing is the user's final place in a closed season
+type Standing struct {
+	SeasonID int64     `json:"season_id"`
+	Slug     string    `json:"slug"`
+	Title    string    `json:"title"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Rank     int64     `json:"rank"`
+	Score    int64     `json:"score"`
+	Reward   int64     `json:"reward"`
+}
+
+// Closing is the outcome of closing a season, Score of a payout is the
+// user's lifetime score right after it
+type Closing struct {
+	Season    *Season
+	Standings int64
+	Payouts   []*Payout
+	Badges    []*Badge
+}
+
+type Payout struct {
+	UserID uuid.UUID
+	Points int64
+	Score  int64
+}
+
+// Badge is a season_rank achievement unlocked by the final standings
+type Badge struct {
+	UserID        uuid.UUID
+	AchievementID int64
+	Slug          string
+	Title         string
+}
+
+// Window is the range the season's points are earned in
+func (s *Season) Window() *leaderboard_domain.Window {
+	return &leaderboard_domain.Window{From: s.StartsAt, To: s.EndsAt}
+}
+
+func (s *Season) ValidateSeason() error {
+	return validate.ValidateStruct(
+		s,
+		validate.Field(&s.Slug, validate.Required, validate.Length(1, 100), validate.Match(slugRegexp)),
+		validate.Field(&s.Title, validate.Required),
+		validate.Field(&s.StartsAt, validate.Required),
+		validate.Field(&s.EndsAt, validate.Required, validate.By(func(value interface{}) error {
+			if !s.EndsAt.After(s.StartsAt) {
+				return fmt.Errorf("must be after starts_at")
+			}
+			return nil
+		})),
+		validate.Field(&s.Rewards, validate.By(func(value interface{}) error {
+			seen := make(map[int64]bool, len(s.Rewards))
+			for _, r := range s.Rewards {
+				if r == nil || r.UpToRank <= 0 || r.Points <= 0 {
+					return fmt.Errorf("up_to_rank and points must be positive")
+				}
+				if seen[r.UpToRank] {
+					return fmt.Errorf("duplicate up_to_rank %d", r.UpToRank)
+				}
+				seen[r.UpToRank] = true
+			}
+			return nil
+		})),
+	)
+}
@@ -0,0 +1,24 @@
+package store_domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type StoreRepository interface {
+	CreateItem(ctx context.Context, item *Item) error
+	GetItem(ctx context.Context, itemID int64) (*Item, error)
+	UpdateItem(ctx context.Context, item *Item) error
+	ListItems(ctx context.Context, activeOnly bool) ([]*Item, error)
+	// Redeem takes the item's cost from the user's spendable points and
+	// records a pending redemption in one transaction
+	Redeem(ctx context.Context, userID uuid.UUID, itemID int64) (*Redemption, error)
+	UserRedemptions(ctx context.Context, userID uuid.UUID) ([]*Redemption, error)
+	ListRedemptions(ctx context.Context, status string) ([]*Redemption, error)
+	// Fulfill marks a pending redemption as handed out
+	Fulfill(ctx context.Context, redemptionID int64) (*Redemption, error)
+	// Refund returns the points and the stock of a pending redemption, one
+	// that was handed out cannot be refunded
+	Refund(ctx context.Context, redemptionID int64) (*Redemption, error)
+}
@@ -0,0 +1,79 @@
+package store_domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	validate "github.com/go-ozzo/ozzo-validation"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrItemNotFound       = errors.New("reward item not found")
+	ErrSlugTaken          = errors.New("reward item with this slug already exists")
+	ErrItemUnavailable    = errors.New("reward item is not available")
+	ErrOutOfStock         = errors.New("reward item is out of stock")
+	ErrLimitReached       = errors.New("redemption limit for this item reached")
+	ErrRedemptionNotFound = errors.New("redemption not found")
+	ErrInvalidTransition  = errors.New("redemption cannot move to this status")
+)
+
+const (
+	StatusPending   = "pending"
+	StatusFulfilled = "fulfilled"
+	StatusRefunded  = "refunded"
+)
+
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Item is a reward that can be bought with spendable points, a nil Stock
+// is unlimited and a nil PerUserLimit lets a user redeem it any number of times
+type Item struct {
+	ItemID       int64     `json:"item_id"`
+	Slug         string    `json:"slug"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Cost         int64     `json:"cost"`
+	Stock        *int64    `json:"stock"`
+	PerUserLimit *int64    `json:"per_user_limit"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Redemption struct {
+	RedemptionID int64     `json:"redemption_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	ItemID       int64     `json:"item_id"`
+	Item         string    `json:"item"`
+	Cost         int64     `json:"cost"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (i *Item) ValidateItem() error {
+	return validate.ValidateStruct(
+		i,
+		validate.Field(&i.Slug, validate.Required, validate.Length(1, 100), validate.Match(slugRegexp)),
+		validate.Field(&i.Title, validate.Required),
+		validate.Field(&i.Cost, validate.Required, validate.Min(1)),
+		validate.Field(&i.Stock, validate.Min(0)),
+		validate.Field(&i.PerUserLimit, validate.By(func(value interface{}) error {
+			if i.PerUserLimit != nil && *i.PerUserLimit < 1 {
+				return fmt.Errorf("must be at least 1")
+			}
+			return nil
+		})),
+	)
+}
+
+func ValidateStatus(status string) error {
+	switch status {
+	case "", StatusPending, StatusFulfilled, StatusRefunded:
+		return nil
+	default:
+		return fmt.Errorf("invalid status, expected one of: %s, %s, %s", StatusPending, StatusFulfilled, StatusRefunded)
+	}
+}
@@ -0,0 +1,12 @@
+package streak_domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type StreakRepository interface {
+	// Freeze spends a freeze to cover a day without completions
+	Freeze(ctx context.Context, userID uuid.UUID) (*Streak, error)
+}
@@ -0,0 +1,74 @@
+package streak_domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNoFreezes      = errors.New("no streak freezes left")
+	ErrNothingToCover = errors.New("today is already covered")
+	ErrStreakLost     = errors.New("streak is already lost")
+)
+
+// Streak counts consecutive days with at least one completed task, LastDay
+// is the latest day covered by a completion or a freeze
+type Streak struct {
+	Current int        `json:"current"`
+	Longest int        `json:"longest"`
+	Freezes int        `json:"freezes"`
+	LastDay *time.Time `json:"last_day"`
+}
+
+// Update is the result of recording a day, Counted is false when the day
+// had already been counted and Score is set when a Bonus was awarded
+type Update struct {
+	Streak  *Streak `json:"streak"`
+	Counted bool    `json:"counted"`
+	Bonus   int64   `json:"bonus"`
+	Score   int64   `json:"score"`
+}
+
+// Rules says when freezes are earned and which streak lengths pay a bonus
+type Rules struct {
+	FreezeEvery int
+	MaxFreezes  int
+	Milestones  map[int]int64
+}
+
+// Next returns s after a completion on today, a streak continues when the
+// previous covered day was yesterday and starts over otherwise
+func (s Streak) Next(today time.Time, rules Rules) Streak {
+	if s.LastDay != nil && s.LastDay.AddDate(0, 0, 1).Equal(today) {
+		s.Current++
+	} else {
+		s.Current = 1
+	}
+
+	if s.Current > s.Longest {
+		s.Longest = s.Current
+	}
+
+	if rules.FreezeEvery > 0 && s.Current%rules.FreezeEvery == 0 && s.Freezes < rules.MaxFreezes {
+		s.Freezes++
+	}
+
+	s.LastDay = &today
+
+	return s
+}
+
+// CheckFreeze tells whether a freeze can cover the day after LastDay, that
+// is today when the user may miss it or yesterday when they already did
+func (s Streak) CheckFreeze(today time.Time) error {
+	switch {
+	case s.Freezes == 0:
+		return ErrNoFreezes
+	case s.LastDay == nil || s.Current == 0 || s.LastDay.AddDate(0, 0, 2).Before(today):
+		return ErrStreakLost
+	case !s.LastDay.Before(today):
+		return ErrNothingToCover
+	}
+
+	return nil
+}
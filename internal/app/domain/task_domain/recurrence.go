@@ -0,0 +1,227 @@
+package task_domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	RecurrenceOnce    = ""
+	RecurrenceDaily   = "daily"
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+
+	// PeriodOnce is the period key of tasks that can be completed a single time
+	PeriodOnce = "once"
+
+	// cron schedules are searched this far back for the latest fire time
+	cronLookbackDays = 366 * 5
+)
+
+// ValidateRecurrence accepts an empty rule, daily, weekly, monthly or a
+// five-field cron expression
+func ValidateRecurrence(rule string) error {
+	switch rule {
+	case RecurrenceOnce, RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+		return nil
+	}
+
+	c, err := parseCron(rule)
+	if err != nil {
+		return fmt.Errorf("invalid recurrence: %w", err)
+	}
+
+	if _, ok := c.prev(time.Now()); !ok {
+		return fmt.Errorf("invalid recurrence: cron expression never fires")
+	}
+
+	return nil
+}
+
+// PeriodKey returns the key of the period now falls into, evaluated in loc.
+// A task is awarded at most once per period key.
+func PeriodKey(rule string, now time.Time, loc *time.Location) (string, error) {
+	t := now.In(loc)
+
+	switch rule {
+	case RecurrenceOnce:
+		return PeriodOnce, nil
+	case RecurrenceDaily:
+		return t.Format("2006-01-02"), nil
+	case RecurrenceWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week), nil
+	case RecurrenceMonthly:
+		return t.Format("2006-01"), nil
+	}
+
+	c, err := parseCron(rule)
+	if err != nil {
+		return "", fmt.Errorf("invalid recurrence: %w", err)
+	}
+
+	start, ok := c.prev(t)
+	if !ok {
+		return "", fmt.Errorf("recurrence %q has no period before %s", rule, t.Format(time.RFC3339))
+	}
+
+	return start.Format("2006-01-02T15:04"), nil
+}
+
+type cronField struct {
+	bits uint64
+	star bool
+}
+
+func (f cronField) has(v int) bool {
+	return f.bits&(1<<uint(v)) != 0
+}
+
+type cronSchedule struct {
+	minute cronField
+	hour   cronField
+	dom    cronField
+	month  cronField
+	dow    cronField
+}
+
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields, got %d", len(fields))
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	parsed := make([]cronField, 5)
+
+	for i, f := range fields {
+		v, err := parseCronField(f, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("field %d %q: %w", i+1, f, err)
+		}
+		parsed[i] = v
+	}
+
+	// both 0 and 7 mean sunday
+	if parsed[4].has(7) {
+		parsed[4].bits |= 1
+	}
+
+	return &cronSchedule{
+		minute: parsed[0],
+		hour:   parsed[1],
+		dom:    parsed[2],
+		month:  parsed[3],
+		dow:    parsed[4],
+	}, nil
+}
+
+func parseCronField(field string, min int, max int) (cronField, error) {
+	f := cronField{star: strings.HasPrefix(field, "*")}
+
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			s, err := strconv.Atoi(stepStr)
+			if err != nil || s <= 0 {
+				return f, fmt.Errorf("invalid step %q", stepStr)
+			}
+			step = s
+		}
+
+		lo, hi := min, max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(a); err != nil {
+				return f, fmt.Errorf("invalid value %q", a)
+			}
+			if hi, err = strconv.Atoi(b); err != nil {
+				return f, fmt.Errorf("invalid value %q", b)
+			}
+		default:
+			v, err := strconv.Atoi(rng)
+			if err != nil {
+				return f, fmt.Errorf("invalid value %q", rng)
+			}
+			lo, hi = v, v
+			if hasStep {
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return f, fmt.Errorf("value out of range %d-%d", min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			f.bits |= 1 << uint(v)
+		}
+	}
+
+	return f, nil
+}
+
+func (c *cronSchedule) matchesDay(t time.Time) bool {
+	if !c.month.has(int(t.Month())) {
+		return false
+	}
+
+	dom := c.dom.has(t.Day())
+	dow := c.dow.has(int(t.Weekday()))
+
+	// like cron, a restricted day of month and day of week match either one
+	switch {
+	case c.dom.star && c.dow.star:
+		return true
+	case c.dom.star:
+		return dow
+	case c.dow.star:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// prev returns the latest fire time at or before t
+func (c *cronSchedule) prev(t time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute)
+	year, month, day := t.Date()
+
+	for d := 0; d <= cronLookbackDays; d++ {
+		date := time.Date(year, month, day-d, 0, 0, 0, 0, t.Location())
+		if !c.matchesDay(date) {
+			continue
+		}
+
+		maxHour := 23
+		if d == 0 {
+			maxHour = t.Hour()
+		}
+
+		for h := maxHour; h >= 0; h-- {
+			if !c.hour.has(h) {
+				continue
+			}
+
+			maxMinute := 59
+			if d == 0 && h == t.Hour() {
+				maxMinute = t.Minute()
+			}
+
+			for m := maxMinute; m >= 0; m-- {
+				if c.minute.has(m) {
+					return time.Date(date.Year(), date.Month(), date.Day(), h, m, 0, 0, t.Location()), true
+				}
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
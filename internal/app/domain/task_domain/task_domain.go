@@ -0,0 +1,27 @@
+package task_domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskRepository interface {
+	CreateTask(ctx context.Context, task *Task) error
+	GetTask(ctx context.Context, taskID int64) (*Task, error)
+	UpdateTask(ctx context.Context, task *Task) error
+	DeactivateTask(ctx context.Context, taskID int64) error
+	ListTasks(ctx context.Context) ([]*Task, error)
+	// AssignTask gives the task to userIDs and returns the number of users
+	// that received it
+	AssignTask(ctx context.Context, taskID int64, userIDs []uuid.UUID) (int64, error)
+	// AssignTaskToAll gives the task to every user and returns the number of
+	// users that received it
+	AssignTaskToAll(ctx context.Context, taskID int64) (int64, error)
+	// LaunchCampaigns activates tasks whose campaign started by now, assigns
+	// them to every user and returns the number of launched tasks
+	LaunchCampaigns(ctx context.Context, now time.Time) (int64, error)
+	// RetireCampaigns deactivates tasks whose campaign ended by now
+	RetireCampaigns(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,101 @@
+package task_domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	validate "github.com/go-ozzo/ozzo-validation"
+)
+
+var (
+	ErrTaskNotFound = errors.New("task not found")
+	ErrSlugTaken    = errors.New("task with this slug already exists")
+	ErrNotStarted   = errors.New("task campaign has not started yet")
+	ErrExpired      = errors.New("task campaign has ended")
+	ErrInactive     = errors.New("task has been deactivated")
+	ErrLocked       = errors.New("task is locked")
+	ErrCycle        = errors.New("task requirements form a cycle")
+)
+
+var (
+	slugRegexp     = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	verifierRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+type Task struct {
+	TaskID        int64      `json:"task_id"`
+	Slug          string     `json:"slug"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Reward        int64      `json:"reward"`
+	Active        bool       `json:"active"`
+	Verifier      string     `json:"verifier"`
+	Recurrence    string     `json:"recurrence"`
+	StartsAt      *time.Time `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at"`
+	Prerequisites []int64    `json:"prerequisites"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func (t *Task) ValidateTask() error {
+	return validate.ValidateStruct(
+		t,
+		validate.Field(&t.Slug, validate.Required, validate.Length(1, 100), validate.Match(slugRegexp)),
+		validate.Field(&t.Title, validate.Required),
+		validate.Field(&t.Reward, validate.Min(0)),
+		validate.Field(&t.Verifier, validate.Required, validate.Length(1, 50), validate.Match(verifierRegexp)),
+		validate.Field(&t.Recurrence, validate.Length(0, 100), validate.By(func(value interface{}) error {
+			return ValidateRecurrence(t.Recurrence)
+		})),
+		validate.Field(&t.EndsAt, validate.By(func(value interface{}) error {
+			if t.StartsAt != nil && t.EndsAt != nil && !t.EndsAt.After(*t.StartsAt) {
+				return fmt.Errorf("must be after starts_at")
+			}
+			return nil
+		})),
+		validate.Field(&t.Prerequisites, validate.By(func(value interface{}) error {
+			seen := make(map[int64]bool, len(t.Prerequisites))
+			for _, id := range t.Prerequisites {
+				switch {
+				case id <= 0:
+					return fmt.Errorf("invalid task_id %d", id)
+				case id == t.TaskID:
+					return fmt.Errorf("task cannot require itself")
+				case seen[id]:
+					return fmt.Errorf("duplicate task_id %d", id)
+				}
+				seen[id] = true
+			}
+			return nil
+		})),
+	)
+}
+
+// CheckWindow reports whether a task with the given campaign window can be
+// completed at now, a nil bound leaves that side of the window open
+func CheckWindow(startsAt *time.Time, endsAt *time.Time, now time.Time) error {
+	if startsAt != nil && now.Before(*startsAt) {
+		return ErrNotStarted
+	}
+
+	if endsAt != nil && !now.Before(*endsAt) {
+		return ErrExpired
+	}
+
+	return nil
+}
+
+// Withdrawn reports whether an inactive task was taken out of circulation.
+// Campaigns are deactivated when they end as well, but they stay listed as
+// expired and their pending submissions can still be approved
+func Withdrawn(active bool, endsAt *time.Time, now time.Time) bool {
+	return !active && (endsAt == nil || now.Before(*endsAt))
+}
+
+// LockedError tells which required tasks are still to be completed
+func LockedError(missing []string) error {
+	return fmt.Errorf("%w: complete %s first", ErrLocked, strings.Join(missing, ", "))
+}
@@ -4,11 +4,30 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
 )
 
+// TelegramLogin checks the payload of the Telegram Login widget and returns
+// the Telegram user id it proves the caller owns
+type TelegramLogin interface {
+	Verify(fields map[string]string) (int64, error)
+}
+
 type UserRepository interface {
 	UserStatus(ctx context.Context, userID uuid.UUID) (*User, error)
-	Leaderboard(ctx context.Context) (map[int]map[string]interface{}, error)
-	CompleteUserTask(ctx context.Context, userID uuid.UUID, task string) error
-	Referrer(ctx context.Context, userID uuid.UUID, referrerID uuid.UUID, task string) error
+	UserTasks(ctx context.Context, userID uuid.UUID, state string) ([]*UserTask, error)
+	// CompleteUserTask awards the task and counts the day of the completion
+	// towards the user's streak under streaks
+	CompleteUserTask(ctx context.Context, userID uuid.UUID, task string, idempotencyKey string, streaks streak_domain.Rules) (*TaskCompletion, error)
+	Referrer(ctx context.Context, userID uuid.UUID, referrerID uuid.UUID, task string) (*Referral, error)
+	LinkTelegram(ctx context.Context, userID uuid.UUID, telegramUserID int64) error
+	TelegramUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	SetTimezone(ctx context.Context, userID uuid.UUID, timezone string) error
+	// TaskSlug returns the slug of the task named by its slug or, until title
+	// lookups are removed, by its title, an unknown name is returned as is
+	TaskSlug(ctx context.Context, task string) (string, error)
+	// MissingRequirements returns the slugs of tasks that have to be
+	// completed before taskID unlocks
+	MissingRequirements(ctx context.Context, userID uuid.UUID, taskID int64) ([]string, error)
+	UserQuests(ctx context.Context, userID uuid.UUID) ([]*UserQuest, error)
 }
@@ -1,17 +1,87 @@
 package user_domain
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/level_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+)
+
+var (
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for another request")
+	ErrTelegramLinked       = errors.New("telegram account is already linked to another user")
+	ErrTelegramLoginInvalid = errors.New("invalid telegram login")
+	ErrTelegramLoginExpired = errors.New("telegram login has expired")
+	ErrTelegramDisabled     = errors.New("telegram linking is not configured")
+)
+
+const (
+	TaskStateAll        = "all"
+	TaskStateCompleted  = "completed"
+	TaskStateIncomplete = "incomplete"
+	TaskStatePending    = "pending"
+	TaskStateRejected   = "rejected"
 )
 
 type User struct {
-	UserID   uuid.UUID `json:"user_id"`
-	Score    int64     `json:"score"`
-	Task     string    `json:"task"`
-	Reward   int64     `json:"reward"`
-	Complete bool      `json:"complete"`
+	UserID        uuid.UUID          `json:"user_id"`
+	Score         int64              `json:"score"`
+	Spendable     int64              `json:"spendable"`
+	Streak        int                `json:"streak"`
+	LongestStreak int                `json:"longest_streak"`
+	StreakFreezes int                `json:"streak_freezes"`
+	Level         level_domain.Level `json:"level"`
+	Task          string             `json:"task"`
+	Reward        int64              `json:"reward"`
+	Complete      bool               `json:"complete"`
+}
+
+type UserTask struct {
+	TaskID       int64      `json:"task_id"`
+	Slug         string     `json:"slug"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	Reward       int64      `json:"reward"`
+	Complete     bool       `json:"complete"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	ReferrerID   *uuid.UUID `json:"referrer_id"`
+	Status       string     `json:"status"`
+	StatusReason string     `json:"status_reason"`
+	Recurrence   string     `json:"recurrence"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	Expired      bool       `json:"expired"`
+	Locked       bool       `json:"locked"`
+}
+
+type UserQuest struct {
+	QuestID     int64        `json:"quest_id"`
+	Slug        string       `json:"slug"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Bonus       int64        `json:"bonus"`
+	Steps       []*QuestStep `json:"steps"`
+	Complete    bool         `json:"complete"`
+	CompletedAt *time.Time   `json:"completed_at"`
+}
+
+type QuestStep struct {
+	TaskID   int64  `json:"task_id"`
+	Slug     string `json:"slug"`
+	Title    string `json:"title"`
+	Complete bool   `json:"complete"`
+}
+
+// QuestBonus is a quest finished by a task completion
+type QuestBonus struct {
+	QuestID int64  `json:"quest_id"`
+	Slug    string `json:"slug"`
+	Bonus   int64  `json:"bonus"`
 }
 
 func (u *User) ValidateUUID() error {
@@ -26,3 +96,76 @@ func (u *User) ValidateUUID() error {
 
 	return nil
 }
+
+// TaskCompletion is the outcome of a completion request, Awarded is false
+// when the task had already been completed before, Score is the user's
+// score right after the award and Streak the day the award counted for
+type TaskCompletion struct {
+	Task        string                `json:"task"`
+	Reward      int64                 `json:"reward"`
+	Awarded     bool                  `json:"awarded"`
+	Period      string                `json:"period"`
+	CompletedAt *time.Time            `json:"completed_at"`
+	Status      string                `json:"status"`
+	Reason      string                `json:"reason"`
+	Quests      []*QuestBonus         `json:"quests"`
+	Score       int64                 `json:"score,omitempty"`
+	Streak      *streak_domain.Update `json:"-"`
+}
+
+// Events announces an awarded completion, the score change comes first so
+// that a level-up is published before the completion is handled
+func (c *TaskCompletion) Events(userID uuid.UUID) []event_domain.Event {
+	events := []event_domain.Event{}
+
+	var bonus int64
+	if c.Streak != nil {
+		bonus = c.Streak.Bonus
+	}
+
+	gained := c.Reward
+	for _, q := range c.Quests {
+		gained += q.Bonus
+	}
+
+	if gained != 0 {
+		events = append(events, event_domain.New(event_domain.TypeScoreChanged, userID, event_domain.ScoreChanged{
+			Reason:   score_domain.ReasonTask,
+			Previous: c.Score - bonus - gained,
+			Score:    c.Score - bonus,
+		}))
+	}
+
+	if bonus != 0 {
+		events = append(events, event_domain.New(event_domain.TypeScoreChanged, userID, event_domain.ScoreChanged{
+			Reason:   score_domain.ReasonStreak,
+			Previous: c.Score - bonus,
+			Score:    c.Score,
+		}))
+	}
+
+	return append(events, event_domain.New(event_domain.TypeTaskCompleted, userID, event_domain.TaskCompleted{
+		Task:   c.Task,
+		Reward: c.Reward,
+		Period: c.Period,
+	}))
+}
+
+// Referral holds the rewards for naming a referrer and the scores of both
+// users right after them
+type Referral struct {
+	Reward         int64 `json:"reward"`
+	Score          int64 `json:"score"`
+	ReferrerReward int64 `json:"referrer_reward"`
+	ReferrerScore  int64 `json:"referrer_score"`
+}
+
+func ValidateTaskState(state string) error {
+	switch state {
+	case TaskStateAll, TaskStateCompleted, TaskStateIncomplete, TaskStatePending, TaskStateRejected:
+		return nil
+	default:
+		return fmt.Errorf("invalid state, expected one of: %s, %s, %s, %s, %s",
+			TaskStateAll, TaskStateCompleted, TaskStateIncomplete, TaskStatePending, TaskStateRejected)
+	}
+}
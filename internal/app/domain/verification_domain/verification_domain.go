@@ -0,0 +1,23 @@
+package verification_domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Verifier decides whether a user has really done a task
+type Verifier interface {
+	Verify(ctx context.Context, req *Request) (*Result, error)
+}
+
+type VerificationRepository interface {
+	UserTaskStatus(ctx context.Context, userID uuid.UUID, task string) (*UserTaskStatus, error)
+	SetStatus(ctx context.Context, userID uuid.UUID, taskID int64, status string, reason string) error
+	ListByStatus(ctx context.Context, status string) ([]*UserTaskStatus, error)
+	// UseNonce records a callback nonce, a nonce seen before fails with
+	// ErrNonceUsed. Nonces older than keep are forgotten, callbacks that old
+	// are rejected by their timestamp anyway
+	UseNonce(ctx context.Context, nonce string, keep time.Duration) error
+}
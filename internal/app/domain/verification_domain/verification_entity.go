@@ -0,0 +1,66 @@
+package verification_domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownVerifier = errors.New("task has no registered verifier")
+	ErrNotPending      = errors.New("task is not awaiting verification")
+	ErrNonceUsed       = errors.New("callback nonce has already been used")
+)
+
+const (
+	StatusNew      = "new"
+	StatusPending  = "pending"
+	StatusVerified = "verified"
+	StatusRejected = "rejected"
+)
+
+const (
+	VerifierNone     = "none"
+	VerifierManual   = "manual"
+	VerifierCallback = "callback"
+	VerifierFake     = "fake"
+	VerifierTelegram = "telegram"
+)
+
+type Request struct {
+	UserID uuid.UUID
+	TaskID int64
+	Task   string
+}
+
+type Result struct {
+	Status string
+	Reason string
+}
+
+type UserTaskStatus struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	TaskID    int64      `json:"task_id"`
+	Task      string     `json:"task"`
+	Reward    int64      `json:"reward"`
+	Verifier  string     `json:"verifier"`
+	Status    string     `json:"status"`
+	Reason    string     `json:"reason"`
+	Complete  bool       `json:"complete"`
+	UpdatedAt *time.Time `json:"updated_at"`
+	StartsAt  *time.Time `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+	Active    bool       `json:"-"`
+}
+
+// ValidateDecision checks the outcome a moderator or an external system reports
+func ValidateDecision(status string) error {
+	switch status {
+	case StatusVerified, StatusRejected:
+		return nil
+	default:
+		return fmt.Errorf("invalid decision, expected %s or %s", StatusVerified, StatusRejected)
+	}
+}
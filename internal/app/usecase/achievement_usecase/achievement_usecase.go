@@ -0,0 +1,134 @@
+package achievement_usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/achievement_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+)
+
+type Service interface {
+	CreateAchievement(ctx context.Context, achievement *achievement_domain.Achievement) error
+	ListAchievements(ctx context.Context) ([]*achievement_domain.Achievement, error)
+	UserAchievements(ctx context.Context, userID uuid.UUID) ([]*achievement_domain.UserAchievement, error)
+	// Evaluate unlocks the achievements the user has reached and publishes
+	// an event for each of them
+	Evaluate(ctx context.Context, userID uuid.UUID) ([]*achievement_domain.UserAchievement, error)
+	// Reconcile unlocks what any user has reached but did not get, e.g.
+	// because an evaluation after a change failed, it returns how many
+	// achievements were unlocked
+	Reconcile(ctx context.Context) (int, error)
+	// HandleEvent evaluates the users whose state the event changed
+	HandleEvent(ctx context.Context, event event_domain.Event) error
+}
+
+// evaluateTimeout bounds the evaluation that follows an event
+const evaluateTimeout = time.Second * 5
+
+type service struct {
+	repository achievement_domain.AchievementRepository
+	events     event_domain.Publisher
+}
+
+func NewService(repository achievement_domain.AchievementRepository, events event_domain.Publisher) Service {
+	return &service{
+		repository: repository,
+		events:     events,
+	}
+}
+
+func (s *service) CreateAchievement(ctx context.Context, achievement *achievement_domain.Achievement) error {
+	if err := achievement.ValidateAchievement(); err != nil {
+		return fmt.Errorf("invalid achievement: %w", err)
+	}
+
+	return s.repository.CreateAchievement(ctx, achievement)
+}
+
+func (s *service) ListAchievements(ctx context.Context) ([]*achievement_domain.Achievement, error) {
+	return s.repository.ListAchievements(ctx)
+}
+
+func (s *service) UserAchievements(ctx context.Context, userID uuid.UUID) ([]*achievement_domain.UserAchievement, error) {
+	u := &user_domain.User{UserID: userID}
+
+	if err := u.ValidateUUID(); err != nil {
+		return nil, err
+	}
+
+	return s.repository.UserAchievements(ctx, userID)
+}
+
+func (s *service) Evaluate(ctx context.Context, userID uuid.UUID) ([]*achievement_domain.UserAchievement, error) {
+	unlocked, err := s.repository.Unlock(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range unlocked {
+		s.publish(ctx, userID, a)
+	}
+
+	return unlocked, nil
+}
+
+func (s *service) Reconcile(ctx context.Context) (int, error) {
+	unlocked, err := s.repository.UnlockAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, a := range unlocked {
+		s.publish(ctx, a.UserID, a)
+	}
+
+	return len(unlocked), nil
+}
+
+func (s *service) HandleEvent(ctx context.Context, event event_domain.Event) error {
+	// the change is committed already, so the evaluation must not end with
+	// the request that made it, what still fails is left to Reconcile
+	if p, ok := event.Payload.(event_domain.ScoreChanged); ok && evaluatedWith(p.Reason) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), evaluateTimeout)
+	defer cancel()
+
+	if _, err := s.Evaluate(ctx, event.UserID); err != nil {
+		return err
+	}
+
+	if p, ok := event.Payload.(event_domain.ReferrerSet); ok {
+		if _, err := s.Evaluate(ctx, p.ReferrerID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// evaluatedWith reports whether a score change is published together with
+// a TaskCompleted or ReferrerSet event, which evaluates the same users once
+// the whole change is saved
+func evaluatedWith(reason string) bool {
+	switch reason {
+	case score_domain.ReasonTask, score_domain.ReasonStreak, score_domain.ReasonReferral, score_domain.ReasonReferrer:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *service) publish(ctx context.Context, userID uuid.UUID, a *achievement_domain.UserAchievement) {
+	s.events.Publish(ctx, event_domain.New(event_domain.TypeAchievementUnlocked, userID, event_domain.AchievementUnlocked{
+		AchievementID: a.AchievementID,
+		Slug:          a.Slug,
+		Title:         a.Title,
+	}))
+}
@@ -17,7 +17,7 @@ type Service interface {
 	GetUser(ctx context.Context, email string, password string) (*auth_domain.User, error)
 	// TODO UpdateUser(ctx context.Context, user *auth.User) error
 	// TODO DeleteUser(ctx context.Context, user *auth.User) error
-	IssueTokens(ctx context.Context, userID uuid.UUID) (accessToken string, refreshToken string, err error)
+	IssueTokens(ctx context.Context, userID uuid.UUID, role string) (accessToken string, refreshToken string, err error)
 	SaveRefreshToken(ctx context.Context, userID uuid.UUID, token string, expiry time.Time) error
 	// TODO GetRefreshToken(ctx context.Context, userID uuid.UUID) (*auth_domain.User, error)
 }
@@ -72,8 +72,8 @@ func (s *service) GetUser(ctx context.Context, email string, password string) (*
 
 // TODO func (s *service) DeleteUser(ctx context.Context, user *auth.User) error {return nil}
 
-func (s *service) IssueTokens(ctx context.Context, userID uuid.UUID) (accessToken string, refreshToken string, err error) {
-	accessToken, err = s.jwt.GenerateAccessToken(userID)
+func (s *service) IssueTokens(ctx context.Context, userID uuid.UUID, role string) (accessToken string, refreshToken string, err error) {
+	accessToken, err = s.jwt.GenerateAccessToken(userID, role)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to generate access tocken: %w", err)
 	}
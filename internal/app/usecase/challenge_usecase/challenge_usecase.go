@@ -0,0 +1,231 @@
+package challenge_usecase
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vo1dFl0w/users-service/internal/app/config"
+)
+
+var (
+	ErrChallengeRequired = errors.New("proof-of-work challenge required")
+	ErrInvalidChallenge  = errors.New("invalid challenge")
+	ErrChallengeExpired  = errors.New("challenge expired")
+	ErrChallengeUsed     = errors.New("challenge already used")
+	ErrDifficultyTooLow  = errors.New("challenge difficulty too low, request a new one")
+	ErrInvalidSolution   = errors.New("invalid challenge solution")
+)
+
+type Challenge struct {
+	Token      string    `json:"challenge"`
+	Difficulty int       `json:"difficulty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type Service interface {
+	Issue(ip string) (*Challenge, error)
+	// Hit registers a request from ip and returns the difficulty the request
+	// has to be solved with, 0 means no challenge is needed
+	Hit(ip string) int
+	Verify(ip string, token string, solution string, difficulty int) error
+}
+
+type bucket struct {
+	count int
+	start time.Time
+}
+
+type service struct {
+	secret []byte
+	cfg    config.ChallengeConfig
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	spent     map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewService(secret []byte, cfg config.ChallengeConfig) Service {
+	return &service{
+		secret:    secret,
+		cfg:       cfg,
+		buckets:   make(map[string]*bucket),
+		spent:     make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *service) Issue(ip string) (*Challenge, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	s.mu.Lock()
+	difficulty := s.difficulty(s.count(ip, time.Now()) + 1)
+	s.mu.Unlock()
+
+	if difficulty == 0 {
+		difficulty = s.cfg.BaseDifficulty
+	}
+
+	expiresAt := time.Now().Add(s.cfg.TTL)
+	payload := fmt.Sprintf("%d.%d.%s", difficulty, expiresAt.Unix(), hex.EncodeToString(nonce))
+
+	token := base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + s.sign(ip, payload)
+
+	return &Challenge{
+		Token:      token,
+		Difficulty: difficulty,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+func (s *service) Hit(ip string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	b, ok := s.buckets[ip]
+	if !ok || now.Sub(b.start) >= s.cfg.Window {
+		b = &bucket{start: now}
+		s.buckets[ip] = b
+	}
+	b.count++
+
+	return s.difficulty(b.count)
+}
+
+func (s *service) Verify(ip string, token string, solution string, difficulty int) error {
+	if token == "" || solution == "" {
+		return ErrChallengeRequired
+	}
+
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalidChallenge
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidChallenge
+	}
+	payload := string(raw)
+
+	if !hmac.Equal([]byte(sig), []byte(s.sign(ip, payload))) {
+		return ErrInvalidChallenge
+	}
+
+	parts := strings.Split(payload, ".")
+	if len(parts) != 3 {
+		return ErrInvalidChallenge
+	}
+
+	tokenDifficulty, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return ErrInvalidChallenge
+	}
+
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return ErrInvalidChallenge
+	}
+	expiresAt := time.Unix(expiry, 0)
+
+	if time.Now().After(expiresAt) {
+		return ErrChallengeExpired
+	}
+
+	if tokenDifficulty < difficulty {
+		return ErrDifficultyTooLow
+	}
+
+	sum := sha256.Sum256([]byte(token + ":" + solution))
+	if leadingZeroBits(sum[:]) < tokenDifficulty {
+		return ErrInvalidSolution
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.spent[token]; ok {
+		return ErrChallengeUsed
+	}
+	s.spent[token] = expiresAt
+
+	return nil
+}
+
+func (s *service) sign(ip string, payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(ip + "|" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// difficulty grows by one bit every time the traffic doubles over the threshold
+func (s *service) difficulty(count int) int {
+	if s.cfg.Threshold <= 0 || count <= s.cfg.Threshold {
+		return 0
+	}
+
+	d := s.cfg.BaseDifficulty + bits.Len(uint(count/s.cfg.Threshold)) - 1
+	if d > s.cfg.MaxDifficulty {
+		d = s.cfg.MaxDifficulty
+	}
+
+	return d
+}
+
+func (s *service) count(ip string, now time.Time) int {
+	b, ok := s.buckets[ip]
+	if !ok || now.Sub(b.start) >= s.cfg.Window {
+		return 0
+	}
+
+	return b.count
+}
+
+func (s *service) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.cfg.Window {
+		return
+	}
+	s.lastSweep = now
+
+	for ip, b := range s.buckets {
+		if now.Sub(b.start) >= s.cfg.Window {
+			delete(s.buckets, ip)
+		}
+	}
+
+	for token, expiresAt := range s.spent {
+		if now.After(expiresAt) {
+			delete(s.spent, token)
+		}
+	}
+}
+
+func leadingZeroBits(b []byte) int {
+	n := 0
+	for _, v := range b {
+		if v == 0 {
+			n += 8
+			continue
+		}
+		n += bits.LeadingZeros8(v)
+		break
+	}
+
+	return n
+}
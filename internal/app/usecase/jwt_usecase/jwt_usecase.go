@@ -9,11 +9,12 @@ import (
 
 type TokenClaims struct {
 	UserID uuid.UUID `json:"user_id"`
+	Role   string    `json:"role"`
 	jwt.StandardClaims
 }
 
 type Service interface {
-	GenerateAccessToken(userID uuid.UUID) (string, error)
+	GenerateAccessToken(userID uuid.UUID, role string) (string, error)
 	GenerateRefreshToken() (string, error)
 	ValidateAccessToken(ctx context.Context, token string) (*TokenClaims, error)
 }
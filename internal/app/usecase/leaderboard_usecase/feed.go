@@ -0,0 +1,256 @@
+package leaderboard_usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+)
+
+// Feed pushes leaderboard changes to live subscribers. Score changes only
+// mark the leaderboard dirty, Run recomputes it at most once per interval
+// and every subscriber keeps just the latest state, so a slow reader skips
+// intermediate states instead of holding the feed back
+type Feed struct {
+	service   Service
+	size      int
+	interval  time.Duration
+	heartbeat time.Duration
+	log       *slog.Logger
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+
+	dirty chan struct{}
+}
+
+func NewFeed(service Service, size int, interval time.Duration, heartbeat time.Duration, log *slog.Logger) *Feed {
+	return &Feed{
+		service:   service,
+		size:      size,
+		interval:  interval,
+		heartbeat: heartbeat,
+		log:       log,
+		subs:      make(map[*Subscription]struct{}),
+		dirty:     make(chan struct{}, 1),
+	}
+}
+
+// Subscription is one live reader of the feed, Ready fires when Next has
+// something new
+type Subscription struct {
+	UserID uuid.UUID
+
+	feed  *Feed
+	ready chan struct{}
+
+	mu       sync.Mutex
+	top      []*leaderboard_domain.Entry
+	rank     *leaderboard_domain.Standing
+	lastTop  []*leaderboard_domain.Entry
+	lastRank *leaderboard_domain.Standing
+}
+
+// Heartbeat is how often idle subscribers should be pinged
+func (f *Feed) Heartbeat() time.Duration {
+	return f.heartbeat
+}
+
+// Top returns the current top the feed tracks
+func (f *Feed) Top(ctx context.Context) ([]*leaderboard_domain.Entry, error) {
+	top, _, err := f.service.Top(ctx, leaderboard_domain.Period{}, "", f.size)
+
+	return top, err
+}
+
+// Subscribe registers the user and queues the current top and rank
+func (f *Feed) Subscribe(ctx context.Context, userID uuid.UUID) (*Subscription, error) {
+	sub := &Subscription{
+		UserID: userID,
+		feed:   f,
+		ready:  make(chan struct{}, 1),
+	}
+
+	top, err := f.Top(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sub.pushTop(top)
+
+	// users without a place yet only get the top until they score
+	rank, err := f.service.Rank(ctx, userID)
+	if err != nil && !errors.Is(err, leaderboard_domain.ErrUserNotFound) {
+		return nil, err
+	}
+	if rank != nil {
+		sub.pushRank(rank)
+	}
+
+	f.mu.Lock()
+	f.subs[sub] = struct{}{}
+	f.mu.Unlock()
+
+	return sub, nil
+}
+
+// HandleEvent marks the leaderboard dirty after a committed score change
+func (f *Feed) HandleEvent(ctx context.Context, event event_domain.Event) error {
+	select {
+	case f.dirty <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Run refreshes the subscribers until ctx is cancelled
+func (f *Feed) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.dirty:
+		}
+
+		f.refresh(ctx)
+
+		// changes arriving meanwhile wait for the next tick
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (f *Feed) refresh(ctx context.Context) {
+	f.mu.Lock()
+	subs := make([]*Subscription, 0, len(f.subs))
+	for sub := range f.subs {
+		subs = append(subs, sub)
+	}
+	f.mu.Unlock()
+
+	if len(subs) == 0 {
+		return
+	}
+
+	top, err := f.Top(ctx)
+	if err != nil {
+		f.log.Error("failed to refresh leaderboard feed", "err", err)
+		return
+	}
+
+	// every subscriber's rank comes from one read, a user with several
+	// connections is looked up once
+	seen := make(map[uuid.UUID]struct{}, len(subs))
+	userIDs := make([]uuid.UUID, 0, len(subs))
+	for _, sub := range subs {
+		if _, ok := seen[sub.UserID]; !ok {
+			seen[sub.UserID] = struct{}{}
+			userIDs = append(userIDs, sub.UserID)
+		}
+	}
+
+	ranks, err := f.service.Ranks(ctx, userIDs)
+	if err != nil {
+		f.log.Error("failed to refresh leaderboard ranks", "err", err)
+	}
+
+	for _, sub := range subs {
+		sub.pushTop(top)
+
+		// users without a place yet only get the top
+		if rank, ok := ranks[sub.UserID]; ok {
+			sub.pushRank(rank)
+		}
+	}
+}
+
+// Ready fires when there is an update to take with Next
+func (s *Subscription) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Next takes the pending update, top or rank is nil when it did not change
+func (s *Subscription) Next() ([]*leaderboard_domain.Entry, *leaderboard_domain.Standing) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	top, rank := s.top, s.rank
+	s.top, s.rank = nil, nil
+
+	return top, rank
+}
+
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	delete(s.feed.subs, s)
+	s.feed.mu.Unlock()
+}
+
+func (s *Subscription) push(top []*leaderboard_domain.Entry, rank *leaderboard_domain.Standing) {
+	s.mu.Lock()
+	if top != nil {
+		s.top = top
+	}
+	if rank != nil {
+		s.rank = rank
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// pushTop queues the top when it differs from the last one queued
+func (s *Subscription) pushTop(top []*leaderboard_domain.Entry) {
+	s.mu.Lock()
+	last := s.lastTop
+	s.lastTop = top
+	s.mu.Unlock()
+
+	if last != nil && sameEntries(last, top) {
+		return
+	}
+
+	s.push(top, nil)
+}
+
+// pushRank queues the rank when it differs from the last one queued
+func (s *Subscription) pushRank(rank *leaderboard_domain.Standing) {
+	s.mu.Lock()
+	last := s.lastRank
+	s.lastRank = rank
+	s.mu.Unlock()
+
+	if last != nil && *last == *rank {
+		return
+	}
+
+	s.push(nil, rank)
+}
+
+func sameEntries(a, b []*leaderboard_domain.Entry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if *a[i] != *b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,193 @@
+package leaderboard_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+)
+
+type Service interface {
+	// Top returns a page of the period's leaderboard and the cursor of the
+	// next page, which is empty on the last page
+	Top(ctx context.Context, period leaderboard_domain.Period, cursor string, limit int) ([]*leaderboard_domain.Entry, string, error)
+	Rank(ctx context.Context, userID uuid.UUID) (*leaderboard_domain.Standing, error)
+	// Ranks returns the places of several users at once, users without a
+	// place are left out
+	Ranks(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*leaderboard_domain.Standing, error)
+	Around(ctx context.Context, userID uuid.UUID, window int) ([]*leaderboard_domain.Entry, error)
+	// Sync reloads the board from the database
+	Sync(ctx context.Context) error
+	// HandleEvent moves the user on the board by a committed score change
+	HandleEvent(ctx context.Context, event event_domain.Event) error
+}
+
+type service struct {
+	repository leaderboard_domain.LeaderboardRepository
+	// board serves the all-time reads when it is set, the repository
+	// serves the rest
+	board leaderboard_domain.Board
+	// location period boundaries are set in
+	location *time.Location
+}
+
+func NewService(repository leaderboard_domain.LeaderboardRepository, board leaderboard_domain.Board, location *time.Location) Service {
+	return &service{
+		repository: repository,
+		board:      board,
+		location:   location,
+	}
+}
+
+func (s *service) Top(ctx context.Context, period leaderboard_domain.Period, cursor string, limit int) ([]*leaderboard_domain.Entry, string, error) {
+	window, err := period.Window(time.Now(), s.location)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if limit == 0 {
+		limit = leaderboard_domain.DefaultLimit
+	}
+
+	if err := leaderboard_domain.ValidateLimit(limit); err != nil {
+		return nil, "", err
+	}
+
+	var after *leaderboard_domain.Cursor
+	if cursor != "" {
+		if after, err = leaderboard_domain.DecodeCursor(cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	var entries []*leaderboard_domain.Entry
+	if window == nil && s.board != nil {
+		entries, err = s.board.Top(ctx, after, limit)
+	} else {
+		entries, err = s.repository.Top(ctx, window, after, limit)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(entries) == limit {
+		next = entries[len(entries)-1].Cursor().Encode()
+	}
+
+	return entries, next, nil
+}
+
+func (s *service) Rank(ctx context.Context, userID uuid.UUID) (*leaderboard_domain.Standing, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("empty user_id")
+	}
+
+	var (
+		st  *leaderboard_domain.Standing
+		err error
+	)
+	if s.board != nil {
+		st, err = s.board.Rank(ctx, userID)
+	}
+	// users who never scored since the last sync are only in the database
+	if s.board == nil || errors.Is(err, leaderboard_domain.ErrUserNotFound) {
+		st, err = s.repository.Rank(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	st.SetPercentile()
+
+	return st, nil
+}
+
+func (s *service) Ranks(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*leaderboard_domain.Standing, error) {
+	standings := make(map[uuid.UUID]*leaderboard_domain.Standing, len(userIDs))
+	missing := userIDs
+
+	if s.board != nil {
+		found, err := s.board.Ranks(ctx, userIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		missing = nil
+		for _, userID := range userIDs {
+			if st, ok := found[userID]; ok {
+				standings[userID] = st
+			} else {
+				missing = append(missing, userID)
+			}
+		}
+	}
+
+	// users who never scored since the last sync are only in the database
+	if len(missing) > 0 {
+		found, err := s.repository.Ranks(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for userID, st := range found {
+			standings[userID] = st
+		}
+	}
+
+	for _, st := range standings {
+		st.SetPercentile()
+	}
+
+	return standings, nil
+}
+
+func (s *service) Around(ctx context.Context, userID uuid.UUID, window int) ([]*leaderboard_domain.Entry, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("empty user_id")
+	}
+
+	if window == 0 {
+		window = leaderboard_domain.DefaultWindow
+	}
+
+	if err := leaderboard_domain.ValidateWindow(window); err != nil {
+		return nil, err
+	}
+
+	if s.board != nil {
+		entries, err := s.board.Around(ctx, userID, window)
+		if !errors.Is(err, leaderboard_domain.ErrUserNotFound) {
+			return entries, err
+		}
+	}
+
+	return s.repository.Around(ctx, userID, window)
+}
+
+func (s *service) Sync(ctx context.Context) error {
+	if s.board == nil {
+		return nil
+	}
+
+	entries, err := s.repository.Scores(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.board.Load(ctx, entries)
+}
+
+func (s *service) HandleEvent(ctx context.Context, event event_domain.Event) error {
+	p, ok := event.Payload.(event_domain.ScoreChanged)
+	if !ok || s.board == nil {
+		return nil
+	}
+
+	// deltas add up in any order, concurrent changes of one user may be
+	// published out of order
+	return s.board.Add(ctx, event.UserID, p.Score-p.Previous)
+}
@@ -0,0 +1,45 @@
+package level_usecase
+
+import (
+	"context"
+
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/level_domain"
+)
+
+type Service interface {
+	// HandleEvent publishes a level-up when a score change crosses a level boundary
+	HandleEvent(ctx context.Context, event event_domain.Event) error
+}
+
+type service struct {
+	curve  level_domain.Curve
+	events event_domain.Publisher
+}
+
+func NewService(curve level_domain.Curve, events event_domain.Publisher) Service {
+	return &service{
+		curve:  curve,
+		events: events,
+	}
+}
+
+func (s *service) HandleEvent(ctx context.Context, event event_domain.Event) error {
+	change, ok := event.Payload.(event_domain.ScoreChanged)
+	if !ok {
+		return nil
+	}
+
+	previous := s.curve.Level(change.Previous)
+	level := s.curve.Level(change.Score)
+
+	if level > previous {
+		s.events.Publish(ctx, event_domain.New(event_domain.TypeLevelUp, event.UserID, event_domain.LevelUp{
+			Previous: previous,
+			Level:    level,
+			Score:    change.Score,
+		}))
+	}
+
+	return nil
+}
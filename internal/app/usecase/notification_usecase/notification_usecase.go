@@ -0,0 +1,358 @@
+package notification_usecase
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/notification_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/leaderboard_usecase"
+)
+
+// Hub delivers notifications to live connections. The last notifications
+// are kept in memory so a client reconnecting with the cursor of the last
+// one it got receives what it missed. Every client has a bounded send
+// queue, a client that lets it fill up is dropped and has to reconnect
+type Hub struct {
+	feed    *leaderboard_usecase.Feed
+	history int
+	queue   int
+	ping    time.Duration
+	log     *slog.Logger
+	epoch   int64
+
+	mu      sync.Mutex
+	seq     uint64
+	records []record
+	clients map[*Client]struct{}
+}
+
+type record struct {
+	seq          uint64
+	notification notification_domain.Notification
+}
+
+func NewHub(feed *leaderboard_usecase.Feed, history int, queue int, ping time.Duration, log *slog.Logger) *Hub {
+	return &Hub{
+		feed:    feed,
+		history: history,
+		queue:   queue,
+		ping:    ping,
+		log:     log,
+		epoch:   time.Now().UnixNano(),
+		clients: make(map[*Client]struct{}),
+	}
+}
+
+// Client is one live connection of a user. Send yields what to write, Done
+// is closed once the client is closed or dropped
+type Client struct {
+	UserID uuid.UUID
+
+	hub  *Hub
+	sub  *leaderboard_usecase.Subscription
+	send chan notification_domain.Notification
+	done chan struct{}
+	once sync.Once
+
+	mu     sync.Mutex
+	topics map[string]struct{}
+	err    error
+}
+
+// Ping is how often connections should be pinged
+func (h *Hub) Ping() time.Duration {
+	return h.ping
+}
+
+// Connect registers a client of the user. After a cursor the client gets
+// the notifications it missed, or a reset when they are no longer kept
+func (h *Hub) Connect(ctx context.Context, userID uuid.UUID, cursor *notification_domain.Cursor, topics []string) (*Client, error) {
+	if err := notification_domain.ValidateTopics(topics); err != nil {
+		return nil, err
+	}
+
+	sub, err := h.feed.Subscribe(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		UserID: userID,
+		hub:    h,
+		sub:    sub,
+		send:   make(chan notification_domain.Notification, h.queue),
+		done:   make(chan struct{}),
+		topics: make(map[string]struct{}),
+	}
+	for _, t := range topics {
+		c.topics[t] = struct{}{}
+	}
+
+	c.enqueue(notification_domain.New(notification_domain.TypeSubscribed, userID, notification_domain.Subscribed{
+		Topics: c.Topics(),
+	}))
+
+	// replaying under the lock keeps notifications published meanwhile
+	// from being missed or sent twice
+	h.mu.Lock()
+	if cursor != nil {
+		h.replay(c, cursor)
+	}
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+
+	go c.forward()
+
+	return c, nil
+}
+
+// HandleEvent turns committed events into notifications
+func (h *Hub) HandleEvent(ctx context.Context, event event_domain.Event) error {
+	var n notification_domain.Notification
+
+	switch p := event.Payload.(type) {
+	case event_domain.TaskReviewed:
+		n = notification_domain.New(notification_domain.TypeTaskReviewed, event.UserID, p)
+	case event_domain.ReferralCredited:
+		n = notification_domain.New(notification_domain.TypeReferralCredited, event.UserID, p)
+	case event_domain.AchievementUnlocked:
+		n = notification_domain.New(notification_domain.TypeBadgeUnlocked, event.UserID, notification_domain.BadgeUnlocked{
+			UserID:        event.UserID,
+			AchievementID: p.AchievementID,
+			Slug:          p.Slug,
+			Title:         p.Title,
+		})
+		n.Topic = notification_domain.TopicBadges
+	default:
+		return nil
+	}
+	n.OccurredAt = event.OccurredAt
+
+	h.publish(n)
+
+	return nil
+}
+
+func (h *Hub) publish(n notification_domain.Notification) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.seq++
+	n.ID = h.cursor().String()
+
+	h.records = append(h.records, record{seq: h.seq, notification: n})
+	if len(h.records) > h.history {
+		h.records = h.records[1:]
+	}
+
+	for c := range h.clients {
+		if c.wants(n) {
+			c.enqueue(n)
+		}
+	}
+}
+
+// replay queues the kept notifications after cursor, h.mu must be held
+func (h *Hub) replay(c *Client, cursor *notification_domain.Cursor) {
+	oldest := h.seq + 1
+	if len(h.records) > 0 {
+		oldest = h.records[0].seq
+	}
+
+	missed := []notification_domain.Notification{}
+	kept := cursor.Epoch == h.epoch && cursor.Seq <= h.seq && cursor.Seq+1 >= oldest
+	if kept {
+		for _, r := range h.records {
+			if r.seq > cursor.Seq && c.wants(r.notification) {
+				missed = append(missed, r.notification)
+			}
+		}
+	}
+
+	// half of the queue is left for what follows right away
+	if !kept || len(missed) > cap(c.send)/2 {
+		reset := notification_domain.New(notification_domain.TypeReset, c.UserID, nil)
+		reset.ID = h.cursor().String()
+		c.enqueue(reset)
+		return
+	}
+
+	for _, n := range missed {
+		c.enqueue(n)
+	}
+}
+
+func (h *Hub) cursor() notification_domain.Cursor {
+	return notification_domain.Cursor{Epoch: h.epoch, Seq: h.seq}
+}
+
+func (c *Client) Send() <-chan notification_domain.Notification {
+	return c.send
+}
+
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err tells why the client was dropped, it is nil after Close
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+// Push queues a reply to the client
+func (c *Client) Push(n notification_domain.Notification) {
+	c.enqueue(n)
+}
+
+// Subscribe adds topics, the current top is queued when the leaderboard
+// is new among them
+func (c *Client) Subscribe(ctx context.Context, topics []string) error {
+	if err := notification_domain.ValidateTopics(topics); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	_, had := c.topics[notification_domain.TopicLeaderboard]
+	for _, t := range topics {
+		c.topics[t] = struct{}{}
+	}
+	_, has := c.topics[notification_domain.TopicLeaderboard]
+	c.mu.Unlock()
+
+	c.enqueue(notification_domain.New(notification_domain.TypeSubscribed, c.UserID, notification_domain.Subscribed{
+		Topics: c.Topics(),
+	}))
+
+	if has && !had {
+		top, err := c.hub.feed.Top(ctx)
+		if err != nil {
+			// the top follows with the next change instead
+			c.hub.log.Error("failed to get leaderboard top", "user_id", c.UserID, "err", err)
+			return nil
+		}
+		c.enqueue(c.top(top))
+	}
+
+	return nil
+}
+
+func (c *Client) Unsubscribe(topics []string) error {
+	if err := notification_domain.ValidateTopics(topics); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	for _, t := range topics {
+		delete(c.topics, t)
+	}
+	c.mu.Unlock()
+
+	c.enqueue(notification_domain.New(notification_domain.TypeSubscribed, c.UserID, notification_domain.Subscribed{
+		Topics: c.Topics(),
+	}))
+
+	return nil
+}
+
+func (c *Client) Topics() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	topics := make([]string, 0, len(c.topics))
+	for t := range c.topics {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+
+	return topics
+}
+
+// Close unregisters the client, it is safe to call more than once
+func (c *Client) Close() {
+	c.drop(nil)
+
+	c.hub.mu.Lock()
+	delete(c.hub.clients, c)
+	c.hub.mu.Unlock()
+
+	c.sub.Close()
+}
+
+// forward turns leaderboard feed updates into rank and top notifications
+func (c *Client) forward() {
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-c.sub.Ready():
+		}
+
+		top, rank := c.sub.Next()
+		if top != nil && c.subscribed(notification_domain.TopicLeaderboard) {
+			c.enqueue(c.top(top))
+		}
+		if rank != nil {
+			c.enqueue(notification_domain.New(notification_domain.TypeRankChanged, c.UserID, rank))
+		}
+	}
+}
+
+func (c *Client) top(entries []*leaderboard_domain.Entry) notification_domain.Notification {
+	n := notification_domain.New(notification_domain.TypeLeaderboardTop, c.UserID, notification_domain.LeaderboardTop{
+		Entries: entries,
+	})
+	n.Topic = notification_domain.TopicLeaderboard
+
+	return n
+}
+
+func (c *Client) wants(n notification_domain.Notification) bool {
+	if n.UserID == c.UserID {
+		return true
+	}
+
+	return n.Topic != "" && c.subscribed(n.Topic)
+}
+
+func (c *Client) subscribed(topic string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.topics[topic]
+
+	return ok
+}
+
+// enqueue never blocks, a client that cannot keep up is dropped
+func (c *Client) enqueue(n notification_domain.Notification) {
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+
+	select {
+	case c.send <- n:
+	default:
+		c.drop(notification_domain.ErrQueueOverflow)
+	}
+}
+
+func (c *Client) drop(err error) {
+	c.once.Do(func() {
+		c.mu.Lock()
+		c.err = err
+		c.mu.Unlock()
+
+		close(c.done)
+	})
+}
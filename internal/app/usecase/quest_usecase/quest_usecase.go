@@ -0,0 +1,82 @@
+package quest_usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vo1dFl0w/users-service/internal/app/domain/quest_domain"
+)
+
+type Service interface {
+	CreateQuest(ctx context.Context, quest *quest_domain.Quest) error
+	UpdateQuest(ctx context.Context, questID int64, update QuestUpdate) (*quest_domain.Quest, error)
+	ListQuests(ctx context.Context) ([]*quest_domain.Quest, error)
+}
+
+// QuestUpdate holds the fields to change, nil fields are left untouched
+type QuestUpdate struct {
+	Slug        *string  `json:"slug"`
+	Title       *string  `json:"title"`
+	Description *string  `json:"description"`
+	Bonus       *int64   `json:"bonus"`
+	Active      *bool    `json:"active"`
+	Steps       *[]int64 `json:"steps"`
+}
+
+type service struct {
+	repository quest_domain.QuestRepository
+}
+
+func NewService(repository quest_domain.QuestRepository) Service {
+	return &service{
+		repository: repository,
+	}
+}
+
+func (s *service) CreateQuest(ctx context.Context, quest *quest_domain.Quest) error {
+	if err := quest.ValidateQuest(); err != nil {
+		return fmt.Errorf("invalid quest: %w", err)
+	}
+
+	return s.repository.CreateQuest(ctx, quest)
+}
+
+func (s *service) UpdateQuest(ctx context.Context, questID int64, update QuestUpdate) (*quest_domain.Quest, error) {
+	q, err := s.repository.GetQuest(ctx, questID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Slug != nil {
+		q.Slug = *update.Slug
+	}
+	if update.Title != nil {
+		q.Title = *update.Title
+	}
+	if update.Description != nil {
+		q.Description = *update.Description
+	}
+	if update.Bonus != nil {
+		q.Bonus = *update.Bonus
+	}
+	if update.Active != nil {
+		q.Active = *update.Active
+	}
+	if update.Steps != nil {
+		q.Steps = *update.Steps
+	}
+
+	if err := q.ValidateQuest(); err != nil {
+		return nil, fmt.Errorf("invalid quest: %w", err)
+	}
+
+	if err := s.repository.UpdateQuest(ctx, q); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+func (s *service) ListQuests(ctx context.Context) ([]*quest_domain.Quest, error) {
+	return s.repository.ListQuests(ctx)
+}
@@ -0,0 +1,91 @@
+package score_usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/config"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+)
+
+type Service interface {
+	// History returns a page of the user's ledger and the cursor of the
+	// next page, which is 0 on the last page
+	History(ctx context.Context, userID uuid.UUID, cursor int64, limit int) ([]*score_domain.LedgerEntry, int64, error)
+	Adjust(ctx context.Context, adjustment *score_domain.Adjustment) (*score_domain.LedgerEntry, error)
+	Transfer(ctx context.Context, transfer *score_domain.Transfer) error
+}
+
+type service struct {
+	repository score_domain.ScoreRepository
+	transfers  config.TransfersConfig
+	events     event_domain.Publisher
+}
+
+func NewService(repository score_domain.ScoreRepository, transfers config.TransfersConfig, events event_domain.Publisher) Service {
+	return &service{
+		repository: repository,
+		transfers:  transfers,
+		events:     events,
+	}
+}
+
+func (s *service) History(ctx context.Context, userID uuid.UUID, cursor int64, limit int) ([]*score_domain.LedgerEntry, int64, error) {
+	u := &user_domain.User{UserID: userID}
+
+	if err := u.ValidateUUID(); err != nil {
+		return nil, 0, err
+	}
+
+	if limit == 0 {
+		limit = score_domain.DefaultHistoryLimit
+	}
+
+	if err := score_domain.ValidateHistoryPage(cursor, limit); err != nil {
+		return nil, 0, err
+	}
+
+	entries, err := s.repository.History(ctx, userID, cursor, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var next int64
+	if len(entries) == limit {
+		next = entries[len(entries)-1].EntryID
+	}
+
+	return entries, next, nil
+}
+
+func (s *service) Adjust(ctx context.Context, adjustment *score_domain.Adjustment) (*score_domain.LedgerEntry, error) {
+	if err := adjustment.ValidateAdjustment(); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.repository.Adjust(ctx, adjustment)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, event_domain.New(event_domain.TypeScoreChanged, adjustment.UserID, event_domain.ScoreChanged{
+		Reason:   score_domain.ReasonAdjustment,
+		Previous: entry.Balance - entry.Amount,
+		Score:    entry.Balance,
+	}))
+
+	return entry, nil
+}
+
+func (s *service) Transfer(ctx context.Context, transfer *score_domain.Transfer) error {
+	if err := transfer.ValidateTransfer(); err != nil {
+		return err
+	}
+
+	return s.repository.Transfer(ctx, transfer, score_domain.TransferLimits{
+		DailyLimit:    s.transfers.DailyLimit,
+		MinAccountAge: s.transfers.MinAccountAge,
+	})
+}
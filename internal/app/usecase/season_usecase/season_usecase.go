@@ -0,0 +1,135 @@
+package season_usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/leaderboard_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/season_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+)
+
+type Service interface {
+	CreateSeason(ctx context.Context, season *season_domain.Season) error
+	ListSeasons(ctx context.Context) ([]*season_domain.Season, error)
+	// Leaderboard returns the season and a page of its leaderboard, live
+	// while the season is open and archived once it is closed
+	Leaderboard(ctx context.Context, seasonID int64, cursor string, limit int) (*season_domain.Season, []*leaderboard_domain.Entry, string, error)
+	UserSeasons(ctx context.Context, userID uuid.UUID) ([]*season_domain.Standing, error)
+	// CloseSeasons closes every season that has ended and publishes the
+	// rewards and achievements it paid, it returns the number of seasons
+	CloseSeasons(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repository  season_domain.SeasonRepository
+	leaderboard leaderboard_domain.LeaderboardRepository
+	events      event_domain.Publisher
+}
+
+func NewService(repository season_domain.SeasonRepository, leaderboard leaderboard_domain.LeaderboardRepository, events event_domain.Publisher) Service {
+	return &service{
+		repository:  repository,
+		leaderboard: leaderboard,
+		events:      events,
+	}
+}
+
+func (s *service) CreateSeason(ctx context.Context, season *season_domain.Season) error {
+	if err := season.ValidateSeason(); err != nil {
+		return fmt.Errorf("invalid season: %w", err)
+	}
+
+	return s.repository.CreateSeason(ctx, season)
+}
+
+func (s *service) ListSeasons(ctx context.Context) ([]*season_domain.Season, error) {
+	return s.repository.ListSeasons(ctx)
+}
+
+func (s *service) Leaderboard(ctx context.Context, seasonID int64, cursor string, limit int) (*season_domain.Season, []*leaderboard_domain.Entry, string, error) {
+	if limit == 0 {
+		limit = leaderboard_domain.DefaultLimit
+	}
+
+	if err := leaderboard_domain.ValidateLimit(limit); err != nil {
+		return nil, nil, "", err
+	}
+
+	var (
+		after *leaderboard_domain.Cursor
+		err   error
+	)
+	if cursor != "" {
+		if after, err = leaderboard_domain.DecodeCursor(cursor); err != nil {
+			return nil, nil, "", err
+		}
+	}
+
+	season, err := s.repository.Season(ctx, seasonID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	var entries []*leaderboard_domain.Entry
+	if season.ClosedAt != nil {
+		entries, err = s.repository.Standings(ctx, seasonID, after, limit)
+	} else {
+		entries, err = s.leaderboard.Top(ctx, season.Window(), after, limit)
+	}
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	var next string
+	if len(entries) == limit {
+		next = entries[len(entries)-1].Cursor().Encode()
+	}
+
+	return season, entries, next, nil
+}
+
+func (s *service) UserSeasons(ctx context.Context, userID uuid.UUID) ([]*season_domain.Standing, error) {
+	u := &user_domain.User{UserID: userID}
+
+	if err := u.ValidateUUID(); err != nil {
+		return nil, err
+	}
+
+	return s.repository.UserStandings(ctx, userID)
+}
+
+func (s *service) CloseSeasons(ctx context.Context) (int, error) {
+	closed := 0
+
+	for {
+		c, err := s.repository.CloseSeason(ctx, time.Now())
+		if err != nil {
+			return closed, err
+		}
+		if c == nil {
+			return closed, nil
+		}
+		closed++
+
+		for _, p := range c.Payouts {
+			s.events.Publish(ctx, event_domain.New(event_domain.TypeScoreChanged, p.UserID, event_domain.ScoreChanged{
+				Reason:   score_domain.ReasonSeason,
+				Previous: p.Score - p.Points,
+				Score:    p.Score,
+			}))
+		}
+
+		for _, b := range c.Badges {
+			s.events.Publish(ctx, event_domain.New(event_domain.TypeAchievementUnlocked, b.UserID, event_domain.AchievementUnlocked{
+				AchievementID: b.AchievementID,
+				Slug:          b.Slug,
+				Title:         b.Title,
+			}))
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package store_usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/store_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+)
+
+type Service interface {
+	CreateItem(ctx context.Context, item *store_domain.Item) error
+	UpdateItem(ctx context.Context, itemID int64, update ItemUpdate) (*store_domain.Item, error)
+	ListItems(ctx context.Context, activeOnly bool) ([]*store_domain.Item, error)
+	Redeem(ctx context.Context, userID uuid.UUID, itemID int64) (*store_domain.Redemption, error)
+	UserRedemptions(ctx context.Context, userID uuid.UUID) ([]*store_domain.Redemption, error)
+	ListRedemptions(ctx context.Context, status string) ([]*store_domain.Redemption, error)
+	// Decide moves a redemption to fulfilled or refunded
+	Decide(ctx context.Context, redemptionID int64, status string) (*store_domain.Redemption, error)
+}
+
+// ItemUpdate holds the fields to change, nil fields are left untouched
+type ItemUpdate struct {
+	Slug         *string `json:"slug"`
+	Title        *string `json:"title"`
+	Description  *string `json:"description"`
+	Cost         *int64  `json:"cost"`
+	Stock        *int64  `json:"stock"`
+	PerUserLimit *int64  `json:"per_user_limit"`
+	Active       *bool   `json:"active"`
+}
+
+type service struct {
+	repository store_domain.StoreRepository
+}
+
+func NewService(repository store_domain.StoreRepository) Service {
+	return &service{
+		repository: repository,
+	}
+}
+
+func (s *service) CreateItem(ctx context.Context, item *store_domain.Item) error {
+	if err := item.ValidateItem(); err != nil {
+		return fmt.Errorf("invalid reward item: %w", err)
+	}
+
+	return s.repository.CreateItem(ctx, item)
+}
+
+func (s *service) UpdateItem(ctx context.Context, itemID int64, update ItemUpdate) (*store_domain.Item, error) {
+	item, err := s.repository.GetItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Slug != nil {
+		item.Slug = *update.Slug
+	}
+	if update.Title != nil {
+		item.Title = *update.Title
+	}
+	if update.Description != nil {
+		item.Description = *update.Description
+	}
+	if update.Cost != nil {
+		item.Cost = *update.Cost
+	}
+	if update.Stock != nil {
+		item.Stock = update.Stock
+	}
+	if update.PerUserLimit != nil {
+		item.PerUserLimit = update.PerUserLimit
+	}
+	if update.Active != nil {
+		item.Active = *update.Active
+	}
+
+	if err := item.ValidateItem(); err != nil {
+		return nil, fmt.Errorf("invalid reward item: %w", err)
+	}
+
+	if err := s.repository.UpdateItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (s *service) ListItems(ctx context.Context, activeOnly bool) ([]*store_domain.Item, error) {
+	return s.repository.ListItems(ctx, activeOnly)
+}
+
+func (s *service) Redeem(ctx context.Context, userID uuid.UUID, itemID int64) (*store_domain.Redemption, error) {
+	u := &user_domain.User{UserID: userID}
+
+	if err := u.ValidateUUID(); err != nil {
+		return nil, err
+	}
+
+	if itemID <= 0 {
+		return nil, fmt.Errorf("invalid item_id")
+	}
+
+	return s.repository.Redeem(ctx, userID, itemID)
+}
+
+func (s *service) UserRedemptions(ctx context.Context, userID uuid.UUID) ([]*store_domain.Redemption, error) {
+	u := &user_domain.User{UserID: userID}
+
+	if err := u.ValidateUUID(); err != nil {
+		return nil, err
+	}
+
+	return s.repository.UserRedemptions(ctx, userID)
+}
+
+func (s *service) ListRedemptions(ctx context.Context, status string) ([]*store_domain.Redemption, error) {
+	if status == "" {
+		status = store_domain.StatusPending
+	}
+
+	if err := store_domain.ValidateStatus(status); err != nil {
+		return nil, err
+	}
+
+	return s.repository.ListRedemptions(ctx, status)
+}
+
+func (s *service) Decide(ctx context.Context, redemptionID int64, status string) (*store_domain.Redemption, error) {
+	switch status {
+	case store_domain.StatusFulfilled:
+		return s.repository.Fulfill(ctx, redemptionID)
+	case store_domain.StatusRefunded:
+		return s.repository.Refund(ctx, redemptionID)
+	default:
+		return nil, store_domain.ErrInvalidTransition
+	}
+}
@@ -0,0 +1,33 @@
+package streak_usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+)
+
+type Service interface {
+	Freeze(ctx context.Context, userID uuid.UUID) (*streak_domain.Streak, error)
+}
+
+type service struct {
+	repository streak_domain.StreakRepository
+}
+
+func NewService(repository streak_domain.StreakRepository) Service {
+	return &service{
+		repository: repository,
+	}
+}
+
+func (s *service) Freeze(ctx context.Context, userID uuid.UUID) (*streak_domain.Streak, error) {
+	u := &user_domain.User{UserID: userID}
+
+	if err := u.ValidateUUID(); err != nil {
+		return nil, err
+	}
+
+	return s.repository.Freeze(ctx, userID)
+}
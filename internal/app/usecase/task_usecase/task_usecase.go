@@ -0,0 +1,158 @@
+package task_usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+)
+
+type Service interface {
+	CreateTask(ctx context.Context, task *task_domain.Task) error
+	UpdateTask(ctx context.Context, taskID int64, update TaskUpdate) (*task_domain.Task, error)
+	DeactivateTask(ctx context.Context, taskID int64) error
+	ListTasks(ctx context.Context) ([]*task_domain.Task, error)
+	// AssignTask gives the task to userIDs, or to every user when all is set,
+	// exactly one of them must be given
+	AssignTask(ctx context.Context, taskID int64, userIDs []uuid.UUID, all bool) (int64, error)
+	// SyncCampaigns launches campaigns that have started and retires the ones
+	// that have ended
+	SyncCampaigns(ctx context.Context) (launched int64, retired int64, err error)
+}
+
+// TaskUpdate holds the fields to change, nil fields are left untouched
+type TaskUpdate struct {
+	Slug          *string    `json:"slug"`
+	Title         *string    `json:"title"`
+	Description   *string    `json:"description"`
+	Reward        *int64     `json:"reward"`
+	Active        *bool      `json:"active"`
+	Verifier      *string    `json:"verifier"`
+	Recurrence    *string    `json:"recurrence"`
+	StartsAt      *time.Time `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at"`
+	Prerequisites *[]int64   `json:"prerequisites"`
+}
+
+type service struct {
+	repository task_domain.TaskRepository
+}
+
+func NewService(repository task_domain.TaskRepository) Service {
+	return &service{
+		repository: repository,
+	}
+}
+
+func (s *service) CreateTask(ctx context.Context, task *task_domain.Task) error {
+	if task.Verifier == "" {
+		task.Verifier = verification_domain.VerifierNone
+	}
+
+	if err := task.ValidateTask(); err != nil {
+		return fmt.Errorf("invalid task: %w", err)
+	}
+
+	// a campaign goes live when the scheduler launches it
+	if task.StartsAt != nil && task.StartsAt.After(time.Now()) {
+		task.Active = false
+	}
+
+	return s.repository.CreateTask(ctx, task)
+}
+
+func (s *service) UpdateTask(ctx context.Context, taskID int64, update TaskUpdate) (*task_domain.Task, error) {
+	t, err := s.repository.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Slug != nil {
+		t.Slug = *update.Slug
+	}
+	if update.Title != nil {
+		t.Title = *update.Title
+	}
+	if update.Description != nil {
+		t.Description = *update.Description
+	}
+	if update.Reward != nil {
+		t.Reward = *update.Reward
+	}
+	if update.Active != nil {
+		t.Active = *update.Active
+	}
+	if update.Verifier != nil {
+		t.Verifier = *update.Verifier
+	}
+	if update.Recurrence != nil {
+		t.Recurrence = *update.Recurrence
+	}
+	if update.StartsAt != nil {
+		t.StartsAt = update.StartsAt
+	}
+	if update.EndsAt != nil {
+		t.EndsAt = update.EndsAt
+	}
+	if update.Prerequisites != nil {
+		t.Prerequisites = *update.Prerequisites
+	}
+
+	if err := t.ValidateTask(); err != nil {
+		return nil, fmt.Errorf("invalid task: %w", err)
+	}
+
+	if err := s.repository.UpdateTask(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *service) DeactivateTask(ctx context.Context, taskID int64) error {
+	return s.repository.DeactivateTask(ctx, taskID)
+}
+
+func (s *service) ListTasks(ctx context.Context) ([]*task_domain.Task, error) {
+	return s.repository.ListTasks(ctx)
+}
+
+func (s *service) AssignTask(ctx context.Context, taskID int64, userIDs []uuid.UUID, all bool) (int64, error) {
+	if all {
+		if len(userIDs) != 0 {
+			return 0, fmt.Errorf("user_ids must be empty when all is set")
+		}
+		return s.repository.AssignTaskToAll(ctx, taskID)
+	}
+
+	if len(userIDs) == 0 {
+		return 0, fmt.Errorf("user_ids is empty, set all to assign the task to every user")
+	}
+
+	for _, id := range userIDs {
+		if id == uuid.Nil {
+			return 0, fmt.Errorf("empty user_id")
+		}
+	}
+
+	return s.repository.AssignTask(ctx, taskID, userIDs)
+}
+
+func (s *service) SyncCampaigns(ctx context.Context) (int64, int64, error) {
+	now := time.Now()
+
+	launched, err := s.repository.LaunchCampaigns(ctx, now)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	retired, err := s.repository.RetireCampaigns(ctx, now)
+	if err != nil {
+		return launched, 0, err
+	}
+
+	return launched, retired, nil
+}
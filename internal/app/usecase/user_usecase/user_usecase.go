@@ -3,25 +3,57 @@ package user_usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/level_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/score_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
 	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
 )
 
 type Service interface {
 	UserStatus(ctx context.Context, userID uuid.UUID) (*user_domain.User, error)
-	Leaderboard(ctx context.Context) (map[int]map[string]interface{}, error)
-	CompleteUserTask(ctx context.Context, userID uuid.UUID, task string) error
+	UserTasks(ctx context.Context, userID uuid.UUID, state string) ([]*user_domain.UserTask, error)
+	CompleteUserTask(ctx context.Context, userID uuid.UUID, task string, idempotencyKey string) (*user_domain.TaskCompletion, error)
 	Referrer(ctx context.Context, userID uuid.UUID, referrerID uuid.UUID, task string) error
+	// LinkTelegram links the Telegram account proven by the login widget
+	// payload
+	LinkTelegram(ctx context.Context, userID uuid.UUID, login map[string]string) error
+	SetTimezone(ctx context.Context, userID uuid.UUID, timezone string) error
+	UserQuests(ctx context.Context, userID uuid.UUID) ([]*user_domain.UserQuest, error)
 }
 
 type service struct {
-	repository user_domain.UserRepository
+	repository    user_domain.UserRepository
+	verifications verification_domain.VerificationRepository
+	verifiers     map[string]verification_domain.Verifier
+	events        event_domain.Publisher
+	levels        level_domain.Curve
+	streaks       streak_domain.Rules
+	telegram      user_domain.TelegramLogin
 }
 
-func NewService(repository user_domain.UserRepository) Service {
+func NewService(
+	repository user_domain.UserRepository,
+	verifications verification_domain.VerificationRepository,
+	verifiers map[string]verification_domain.Verifier,
+	events event_domain.Publisher,
+	levels level_domain.Curve,
+	streaks streak_domain.Rules,
+	telegram user_domain.TelegramLogin,
+) Service {
 	return &service{
-		repository: repository,
+		repository:    repository,
+		verifications: verifications,
+		verifiers:     verifiers,
+		events:        events,
+		levels:        levels,
+		streaks:       streaks,
+		telegram:      telegram,
 	}
 }
 
@@ -32,25 +64,118 @@ func (s *service) UserStatus(ctx context.Context, userID uuid.UUID) (*user_domai
 		return nil, err
 	}
 
-	return s.repository.UserStatus(ctx, userID)
+	usr, err := s.repository.UserStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	usr.Level = level_domain.Progress(s.levels, usr.Score)
+
+	return usr, nil
 }
 
-func (s *service) Leaderboard(ctx context.Context) (map[int]map[string]interface{}, error) {
-	return s.repository.Leaderboard(ctx)
+func (s *service) UserTasks(ctx context.Context, userID uuid.UUID, state string) ([]*user_domain.UserTask, error) {
+	u := &user_domain.User{UserID: userID}
+
+	if err := u.ValidateUUID(); err != nil {
+		return nil, err
+	}
+
+	if state == "" {
+		state = user_domain.TaskStateAll
+	}
+
+	if err := user_domain.ValidateTaskState(state); err != nil {
+		return nil, err
+	}
+
+	return s.repository.UserTasks(ctx, userID, state)
 }
 
-func (s *service) CompleteUserTask(ctx context.Context, userID uuid.UUID, task string) error {
+func (s *service) CompleteUserTask(ctx context.Context, userID uuid.UUID, task string, idempotencyKey string) (*user_domain.TaskCompletion, error) {
 	u := &user_domain.User{UserID: userID}
 
 	if err := u.ValidateUUID(); err != nil {
-		return err
+		return nil, err
 	}
 
 	if task == "" {
-		return fmt.Errorf("empty task")
+		return nil, fmt.Errorf("empty task")
+	}
+
+	if len(idempotencyKey) > 255 {
+		return nil, fmt.Errorf("idempotency key is too long")
+	}
+
+	task, err := s.repository.TaskSlug(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := s.verifications.UserTaskStatus(ctx, userID, task)
+	if err != nil {
+		return nil, err
+	}
+
+	if task_domain.Withdrawn(st.Active, st.EndsAt, time.Now()) {
+		return nil, task_domain.ErrInactive
+	}
+
+	if !st.Complete {
+		if err := task_domain.CheckWindow(st.StartsAt, st.EndsAt, time.Now()); err != nil {
+			return nil, err
+		}
+
+		missing, err := s.repository.MissingRequirements(ctx, userID, st.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		if len(missing) > 0 {
+			return nil, task_domain.LockedError(missing)
+		}
+	}
+
+	if !st.Complete && st.Verifier != verification_domain.VerifierNone {
+		v, ok := s.verifiers[st.Verifier]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", verification_domain.ErrUnknownVerifier, st.Verifier)
+		}
+
+		res, err := v.Verify(ctx, &verification_domain.Request{
+			UserID: userID,
+			TaskID: st.TaskID,
+			Task:   st.Task,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify task: %w", err)
+		}
+
+		if res.Status != verification_domain.StatusVerified {
+			if err := s.verifications.SetStatus(ctx, userID, st.TaskID, res.Status, res.Reason); err != nil {
+				return nil, err
+			}
+
+			return &user_domain.TaskCompletion{
+				Task:   st.Task,
+				Reward: st.Reward,
+				Status: res.Status,
+				Reason: res.Reason,
+			}, nil
+		}
+	}
+
+	completion, err := s.repository.CompleteUserTask(ctx, userID, task, idempotencyKey, s.streaks)
+	if err != nil {
+		return nil, err
+	}
+
+	if completion.Awarded {
+		for _, e := range completion.Events(userID) {
+			s.events.Publish(ctx, e)
+		}
 	}
 
-	return s.repository.CompleteUserTask(ctx, userID, task)
+	return completion, nil
 }
 
 func (s *service) Referrer(ctx context.Context, userID uuid.UUID, referrerID uuid.UUID, task string) error {
@@ -68,5 +193,84 @@ func (s *service) Referrer(ctx context.Context, userID uuid.UUID, referrerID uui
 		return fmt.Errorf("empty task")
 	}
 
-	return s.repository.Referrer(ctx, userID, referrerID, task)
+	task, err := s.repository.TaskSlug(ctx, task)
+	if err != nil {
+		return err
+	}
+
+	ref, err := s.repository.Referrer(ctx, userID, referrerID, task)
+	if err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, event_domain.New(event_domain.TypeScoreChanged, referrerID, event_domain.ScoreChanged{
+		Reason:   score_domain.ReasonReferrer,
+		Previous: ref.ReferrerScore - ref.ReferrerReward,
+		Score:    ref.ReferrerScore,
+	}))
+	s.events.Publish(ctx, event_domain.New(event_domain.TypeScoreChanged, userID, event_domain.ScoreChanged{
+		Reason:   score_domain.ReasonReferral,
+		Previous: ref.Score - ref.Reward,
+		Score:    ref.Score,
+	}))
+	s.events.Publish(ctx, event_domain.New(event_domain.TypeReferrerSet, userID, event_domain.ReferrerSet{
+		ReferrerID: referrerID,
+		Task:       task,
+	}))
+	s.events.Publish(ctx, event_domain.New(event_domain.TypeReferralCredited, referrerID, event_domain.ReferralCredited{
+		ReferralID: userID,
+		Task:       task,
+		Reward:     ref.ReferrerReward,
+		Score:      ref.ReferrerScore,
+	}))
+
+	return nil
+}
+
+func (s *service) LinkTelegram(ctx context.Context, userID uuid.UUID, login map[string]string) error {
+	u := &user_domain.User{UserID: userID}
+
+	if err := u.ValidateUUID(); err != nil {
+		return err
+	}
+
+	// a bare id proves nothing, anyone could link a known channel member
+	if s.telegram == nil {
+		return user_domain.ErrTelegramDisabled
+	}
+
+	telegramUserID, err := s.telegram.Verify(login)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.LinkTelegram(ctx, userID, telegramUserID)
+}
+
+func (s *service) SetTimezone(ctx context.Context, userID uuid.UUID, timezone string) error {
+	u := &user_domain.User{UserID: userID}
+
+	if err := u.ValidateUUID(); err != nil {
+		return err
+	}
+
+	if timezone == "" || timezone == "Local" {
+		return fmt.Errorf("invalid timezone")
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	return s.repository.SetTimezone(ctx, userID, timezone)
+}
+
+func (s *service) UserQuests(ctx context.Context, userID uuid.UUID) ([]*user_domain.UserQuest, error) {
+	u := &user_domain.User{UserID: userID}
+
+	if err := u.ValidateUUID(); err != nil {
+		return nil, err
+	}
+
+	return s.repository.UserQuests(ctx, userID)
 }
@@ -0,0 +1,149 @@
+package user_usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/adapters/verifier"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/user_usecase"
+)
+
+const task = "follow"
+
+// users awards every completion it is asked for, the methods the tests do
+// not reach are left to the embedded nil interface
+type users struct {
+	user_domain.UserRepository
+	completed int
+}
+
+func (u *users) TaskSlug(ctx context.Context, task string) (string, error) {
+	return task, nil
+}
+
+func (u *users) MissingRequirements(ctx context.Context, userID uuid.UUID, taskID int64) ([]string, error) {
+	return nil, nil
+}
+
+func (u *users) CompleteUserTask(ctx context.Context, userID uuid.UUID, task string, idempotencyKey string, streaks streak_domain.Rules) (*user_domain.TaskCompletion, error) {
+	u.completed++
+	now := time.Now()
+
+	return &user_domain.TaskCompletion{
+		Task:        task,
+		Reward:      50,
+		Awarded:     true,
+		CompletedAt: &now,
+		Status:      verification_domain.StatusVerified,
+		Score:       150,
+	}, nil
+}
+
+type verifications struct {
+	verification_domain.VerificationRepository
+	status *verification_domain.UserTaskStatus
+	set    []string
+}
+
+func (v *verifications) UserTaskStatus(ctx context.Context, userID uuid.UUID, task string) (*verification_domain.UserTaskStatus, error) {
+	st := *v.status
+	st.UserID = userID
+
+	return &st, nil
+}
+
+func (v *verifications) SetStatus(ctx context.Context, userID uuid.UUID, taskID int64, status string, reason string) error {
+	v.set = append(v.set, status)
+
+	return nil
+}
+
+type publisher struct {
+	events []event_domain.Event
+}
+
+func (p *publisher) Publish(ctx context.Context, event event_domain.Event) {
+	p.events = append(p.events, event)
+}
+
+func TestCompleteUserTaskVerification(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		reason string
+		award  bool
+	}{
+		{name: "pending", status: verification_domain.StatusPending},
+		{name: "rejected", status: verification_domain.StatusRejected, reason: "not a member"},
+		{name: "verified", status: verification_domain.StatusVerified, award: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := verifier.NewFake()
+			fake.Set(task, tt.status, tt.reason)
+
+			repo := &users{}
+			checks := &verifications{status: &verification_domain.UserTaskStatus{
+				TaskID:   1,
+				Task:     task,
+				Reward:   50,
+				Verifier: verification_domain.VerifierFake,
+				Status:   verification_domain.StatusNew,
+				Active:   true,
+			}}
+			events := &publisher{}
+
+			service := user_usecase.NewService(repo, checks, map[string]verification_domain.Verifier{
+				verification_domain.VerifierFake: fake,
+			}, events, nil, streak_domain.Rules{}, nil)
+
+			userID := uuid.New()
+			c, err := service.CompleteUserTask(context.Background(), userID, task, "")
+			if err != nil {
+				t.Fatalf("CompleteUserTask() error = %v", err)
+			}
+
+			if len(fake.Calls) != 1 || fake.Calls[0].UserID != userID || fake.Calls[0].Task != task {
+				t.Errorf("verifier calls = %+v, want one for %s", fake.Calls, task)
+			}
+			if c.Status != tt.status || c.Reason != tt.reason {
+				t.Errorf("completion status = %q %q, want %q %q", c.Status, c.Reason, tt.status, tt.reason)
+			}
+
+			if !tt.award {
+				if c.Awarded || repo.completed != 0 {
+					t.Errorf("awarded = %v after %d completions, want no award", c.Awarded, repo.completed)
+				}
+				if len(events.events) != 0 {
+					t.Errorf("published %d events, want none", len(events.events))
+				}
+				if len(checks.set) != 1 || checks.set[0] != tt.status {
+					t.Errorf("stored statuses = %v, want [%s]", checks.set, tt.status)
+				}
+				return
+			}
+
+			if !c.Awarded || repo.completed != 1 {
+				t.Errorf("awarded = %v after %d completions, want one award", c.Awarded, repo.completed)
+			}
+			if len(checks.set) != 0 {
+				t.Errorf("stored statuses = %v, want none", checks.set)
+			}
+
+			types := []string{}
+			for _, e := range events.events {
+				types = append(types, e.Type)
+			}
+			if len(types) != 2 || types[0] != event_domain.TypeScoreChanged || types[1] != event_domain.TypeTaskCompleted {
+				t.Errorf("published %v, want [%s %s]", types, event_domain.TypeScoreChanged, event_domain.TypeTaskCompleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package verification_usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/task_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+)
+
+var (
+	ErrCallbacksDisabled = errors.New("verification callbacks are disabled")
+	ErrInvalidSignature  = errors.New("invalid callback signature")
+	ErrStaleCallback     = errors.New("callback timestamp is too old or in the future")
+)
+
+type Service interface {
+	List(ctx context.Context, status string) ([]*verification_domain.UserTaskStatus, error)
+	Decide(ctx context.Context, userID uuid.UUID, task string, status string, reason string) error
+	Callback(ctx context.Context, body []byte, signature string) error
+}
+
+type service struct {
+	repository     verification_domain.VerificationRepository
+	users          user_domain.UserRepository
+	callbackSecret []byte
+	callbackMaxAge time.Duration
+	events         event_domain.Publisher
+	streaks        streak_domain.Rules
+}
+
+func NewService(
+	repository verification_domain.VerificationRepository,
+	users user_domain.UserRepository,
+	callbackSecret []byte,
+	callbackMaxAge time.Duration,
+	events event_domain.Publisher,
+	streaks streak_domain.Rules,
+) Service {
+	return &service{
+		repository:     repository,
+		users:          users,
+		callbackSecret: callbackSecret,
+		callbackMaxAge: callbackMaxAge,
+		events:         events,
+		streaks:        streaks,
+	}
+}
+
+func (s *service) List(ctx context.Context, status string) ([]*verification_domain.UserTaskStatus, error) {
+	if status == "" {
+		status = verification_domain.StatusPending
+	}
+
+	return s.repository.ListByStatus(ctx, status)
+}
+
+func (s *service) Decide(ctx context.Context, userID uuid.UUID, task string, status string, reason string) error {
+	st, err := s.pending(ctx, userID, task, status)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(ctx, st, status, reason)
+}
+
+// Callback applies a decision reported by an external system, the body has
+// to be signed with hex(hmac_sha256(callback_secret, body)). The signed body
+// carries a timestamp and a nonce, so a captured callback is rejected once it
+// is older than callbackMaxAge and cannot be replayed before that
+func (s *service) Callback(ctx context.Context, body []byte, signature string) error {
+	if len(s.callbackSecret) == 0 {
+		return ErrCallbacksDisabled
+	}
+
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, s.callbackSecret)
+	mac.Write(body)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	req := struct {
+		UserID    uuid.UUID `json:"user_id"`
+		Task      string    `json:"task"`
+		Status    string    `json:"status"`
+		Reason    string    `json:"reason"`
+		Timestamp int64     `json:"timestamp"`
+		Nonce     string    `json:"nonce"`
+	}{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return fmt.Errorf("invalid callback body: %w", err)
+	}
+
+	if req.Nonce == "" || len(req.Nonce) > 100 {
+		return fmt.Errorf("invalid callback nonce")
+	}
+
+	sent := time.Unix(req.Timestamp, 0)
+	if age := time.Since(sent); age > s.callbackMaxAge || age < -s.callbackMaxAge {
+		return ErrStaleCallback
+	}
+
+	st, err := s.pending(ctx, req.UserID, req.Task, req.Status)
+	if err != nil {
+		return err
+	}
+
+	if st.Verifier != verification_domain.VerifierCallback {
+		return fmt.Errorf("task is not verified by callback")
+	}
+
+	// the nonce is spent even if applying fails, a retry is signed anew
+	if err := s.repository.UseNonce(ctx, req.Nonce, s.callbackMaxAge*2); err != nil {
+		return err
+	}
+
+	return s.apply(ctx, st, req.Status, req.Reason)
+}
+
+func (s *service) pending(ctx context.Context, userID uuid.UUID, task string, status string) (*verification_domain.UserTaskStatus, error) {
+	u := &user_domain.User{UserID: userID}
+
+	if err := u.ValidateUUID(); err != nil {
+		return nil, err
+	}
+
+	if task == "" {
+		return nil, fmt.Errorf("empty task")
+	}
+
+	if err := verification_domain.ValidateDecision(status); err != nil {
+		return nil, err
+	}
+
+	st, err := s.repository.UserTaskStatus(ctx, userID, task)
+	if err != nil {
+		return nil, err
+	}
+
+	if st.Status != verification_domain.StatusPending {
+		return nil, verification_domain.ErrNotPending
+	}
+
+	// submissions of a withdrawn task can still be rejected to clear them
+	if status == verification_domain.StatusVerified && task_domain.Withdrawn(st.Active, st.EndsAt, time.Now()) {
+		return nil, task_domain.ErrInactive
+	}
+
+	return st, nil
+}
+
+func (s *service) apply(ctx context.Context, st *verification_domain.UserTaskStatus, status string, reason string) error {
+	reviewed := event_domain.TaskReviewed{
+		Task:   st.Task,
+		Status: status,
+		Reason: reason,
+	}
+
+	if status == verification_domain.StatusRejected {
+		if err := s.repository.SetStatus(ctx, st.UserID, st.TaskID, status, reason); err != nil {
+			return err
+		}
+
+		s.events.Publish(ctx, event_domain.New(event_domain.TypeTaskReviewed, st.UserID, reviewed))
+
+		return nil
+	}
+
+	completion, err := s.users.CompleteUserTask(ctx, st.UserID, st.Task, "", s.streaks)
+	if err != nil {
+		return err
+	}
+
+	if completion.Awarded {
+		for _, e := range completion.Events(st.UserID) {
+			s.events.Publish(ctx, e)
+		}
+		reviewed.Reward = completion.Reward
+	}
+
+	s.events.Publish(ctx, event_domain.New(event_domain.TypeTaskReviewed, st.UserID, reviewed))
+
+	return nil
+}
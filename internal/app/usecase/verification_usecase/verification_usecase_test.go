@@ -0,0 +1,122 @@
+package verification_usecase_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/event_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/streak_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/user_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/domain/verification_domain"
+	"github.com/vo1dFl0w/users-service/internal/app/usecase/verification_usecase"
+)
+
+var secret = []byte("secret")
+
+// verifications keeps one pending callback task, the methods the test does
+// not reach are left to the embedded nil interface
+type verifications struct {
+	verification_domain.VerificationRepository
+	nonces   map[string]bool
+	rejected int
+}
+
+func (v *verifications) UserTaskStatus(ctx context.Context, userID uuid.UUID, task string) (*verification_domain.UserTaskStatus, error) {
+	return &verification_domain.UserTaskStatus{
+		UserID:   userID,
+		TaskID:   1,
+		Task:     task,
+		Verifier: verification_domain.VerifierCallback,
+		Status:   verification_domain.StatusPending,
+		Active:   true,
+	}, nil
+}
+
+func (v *verifications) SetStatus(ctx context.Context, userID uuid.UUID, taskID int64, status string, reason string) error {
+	v.rejected++
+
+	return nil
+}
+
+func (v *verifications) UseNonce(ctx context.Context, nonce string, keep time.Duration) error {
+	if v.nonces[nonce] {
+		return verification_domain.ErrNonceUsed
+	}
+	v.nonces[nonce] = true
+
+	return nil
+}
+
+type publisher struct{}
+
+func (publisher) Publish(ctx context.Context, event event_domain.Event) {}
+
+func callback(t *testing.T, timestamp time.Time, nonce string) ([]byte, string) {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]interface{}{
+		"user_id":   uuid.New(),
+		"task":      "follow",
+		"status":    verification_domain.StatusRejected,
+		"timestamp": timestamp.Unix(),
+		"nonce":     nonce,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return body, hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestCallback(t *testing.T) {
+	repo := &verifications{nonces: make(map[string]bool)}
+	var users user_domain.UserRepository
+	service := verification_usecase.NewService(repo, users, secret, time.Minute*5, publisher{}, streak_domain.Rules{})
+
+	ctx := context.Background()
+
+	body, sig := callback(t, time.Now(), "first")
+	if err := service.Callback(ctx, body, sig); err != nil {
+		t.Fatalf("Callback() error = %v", err)
+	}
+
+	if err := service.Callback(ctx, body, sig); !errors.Is(err, verification_domain.ErrNonceUsed) {
+		t.Errorf("replayed Callback() error = %v, want %v", err, verification_domain.ErrNonceUsed)
+	}
+
+	body, sig = callback(t, time.Now().Add(-time.Hour), "stale")
+	if err := service.Callback(ctx, body, sig); !errors.Is(err, verification_usecase.ErrStaleCallback) {
+		t.Errorf("stale Callback() error = %v, want %v", err, verification_usecase.ErrStaleCallback)
+	}
+
+	body, sig = callback(t, time.Now().Add(time.Hour), "future")
+	if err := service.Callback(ctx, body, sig); !errors.Is(err, verification_usecase.ErrStaleCallback) {
+		t.Errorf("future Callback() error = %v, want %v", err, verification_usecase.ErrStaleCallback)
+	}
+
+	body, _ = callback(t, time.Now(), "unsigned")
+	if err := service.Callback(ctx, body, hex.EncodeToString([]byte("nope"))); !errors.Is(err, verification_usecase.ErrInvalidSignature) {
+		t.Errorf("unsigned Callback() error = %v, want %v", err, verification_usecase.ErrInvalidSignature)
+	}
+
+	if body, sig = callback(t, time.Now(), ""); service.Callback(ctx, body, sig) == nil {
+		t.Errorf("Callback() without a nonce succeeded")
+	}
+
+	if repo.rejected != 1 {
+		t.Errorf("applied %d decisions, want 1", repo.rejected)
+	}
+	if repo.nonces["stale"] || repo.nonces["future"] || repo.nonces["unsigned"] {
+		t.Errorf("rejected callbacks spent their nonces: %v", repo.nonces)
+	}
+}
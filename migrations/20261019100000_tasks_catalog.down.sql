@@ -0,0 +1,10 @@
+ALTER TABLE users_tasks ADD COLUMN task TEXT;
+ALTER TABLE users_tasks ADD COLUMN reward BIGINT NOT NULL DEFAULT 0 CHECK (reward >= 0);
+
+UPDATE users_tasks ut SET task = t.title, reward = t.reward FROM tasks t WHERE t.task_id = ut.task_id;
+
+ALTER TABLE users_tasks DROP CONSTRAINT users_tasks_pkey;
+ALTER TABLE users_tasks DROP COLUMN task_id;
+ALTER TABLE users_tasks ADD PRIMARY KEY (user_id, task);
+
+DROP TABLE tasks;
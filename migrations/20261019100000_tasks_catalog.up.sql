@@ -0,0 +1,31 @@
+CREATE TABLE tasks (
+    task_id SERIAL PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    reward BIGINT NOT NULL DEFAULT 0 CHECK (reward >= 0),
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO tasks (slug, title, reward) VALUES
+    ('subscribe-telegram', 'subscribe to ''telegram'' channel/group', 150),
+    ('subscribe-instagram', 'subscribe to ''instagram'' account', 150),
+    ('subscribe-vkontakte', 'subscribe to ''vkontakte'' group', 150);
+
+INSERT INTO tasks (slug, title, reward, active)
+SELECT DISTINCT ON (task) task, task, reward, false
+FROM users_tasks
+WHERE task IS NOT NULL AND task NOT IN (SELECT title FROM tasks);
+
+ALTER TABLE users_tasks ADD COLUMN task_id INT REFERENCES tasks (task_id) ON DELETE CASCADE;
+
+UPDATE users_tasks ut SET task_id = t.task_id FROM tasks t WHERE t.title = ut.task;
+
+DELETE FROM users_tasks WHERE task_id IS NULL;
+
+ALTER TABLE users_tasks DROP CONSTRAINT users_tasks_pkey;
+ALTER TABLE users_tasks ALTER COLUMN task_id SET NOT NULL;
+ALTER TABLE users_tasks DROP COLUMN task;
+ALTER TABLE users_tasks DROP COLUMN reward;
+ALTER TABLE users_tasks ADD PRIMARY KEY (user_id, task_id);
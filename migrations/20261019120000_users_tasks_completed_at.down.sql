@@ -0,0 +1 @@
+ALTER TABLE users_tasks DROP COLUMN completed_at;
@@ -0,0 +1 @@
+ALTER TABLE users_tasks ADD COLUMN completed_at TIMESTAMPTZ NULL;
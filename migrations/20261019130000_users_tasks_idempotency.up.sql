@@ -0,0 +1,10 @@
+CREATE TABLE users_tasks_idempotency (
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    idempotency_key VARCHAR(255) NOT NULL,
+    task_id INT NOT NULL REFERENCES tasks (task_id) ON DELETE CASCADE,
+    awarded BOOLEAN NOT NULL,
+    reward BIGINT NOT NULL,
+    completed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, idempotency_key)
+);
@@ -0,0 +1,8 @@
+DROP INDEX idx_users_tasks_status;
+
+ALTER TABLE users_tasks DROP COLUMN status_updated_at;
+ALTER TABLE users_tasks DROP COLUMN status_reason;
+ALTER TABLE users_tasks DROP COLUMN status;
+ALTER TABLE users_tasks ALTER COLUMN complete DROP NOT NULL;
+
+ALTER TABLE tasks DROP COLUMN verifier;
@@ -0,0 +1,13 @@
+ALTER TABLE tasks ADD COLUMN verifier VARCHAR(50) NOT NULL DEFAULT 'none';
+
+UPDATE users_tasks SET complete = false WHERE complete IS NULL;
+ALTER TABLE users_tasks ALTER COLUMN complete SET NOT NULL;
+
+ALTER TABLE users_tasks ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'new'
+    CHECK (status IN ('new', 'pending', 'verified', 'rejected'));
+ALTER TABLE users_tasks ADD COLUMN status_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE users_tasks ADD COLUMN status_updated_at TIMESTAMPTZ NULL;
+
+UPDATE users_tasks SET status = 'verified', status_updated_at = completed_at WHERE complete;
+
+CREATE INDEX idx_users_tasks_status ON users_tasks (status) WHERE status = 'pending';
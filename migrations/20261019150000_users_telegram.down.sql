@@ -0,0 +1,3 @@
+UPDATE tasks SET verifier = 'none' WHERE slug = 'subscribe-telegram' AND verifier = 'telegram';
+
+ALTER TABLE users DROP COLUMN telegram_user_id;
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN telegram_user_id BIGINT NULL UNIQUE;
+
+UPDATE tasks SET verifier = 'telegram' WHERE slug = 'subscribe-telegram';
@@ -0,0 +1,8 @@
+DROP INDEX idx_users_tasks_completions_completed_at;
+DROP TABLE users_tasks_completions;
+
+ALTER TABLE users_tasks_idempotency DROP COLUMN period_key;
+
+ALTER TABLE users DROP COLUMN timezone;
+
+ALTER TABLE tasks DROP COLUMN recurrence;
@@ -0,0 +1,23 @@
+ALTER TABLE tasks ADD COLUMN recurrence VARCHAR(100) NOT NULL DEFAULT '';
+
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+CREATE TABLE users_tasks_completions (
+    user_id UUID NOT NULL,
+    task_id INT NOT NULL,
+    period_key VARCHAR(32) NOT NULL,
+    reward BIGINT NOT NULL,
+    completed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, task_id, period_key),
+    FOREIGN KEY (user_id, task_id) REFERENCES users_tasks (user_id, task_id) ON DELETE CASCADE
+);
+
+ALTER TABLE users_tasks_idempotency ADD COLUMN period_key VARCHAR(32) NOT NULL DEFAULT 'once';
+
+CREATE INDEX idx_users_tasks_completions_completed_at ON users_tasks_completions (user_id, task_id, completed_at DESC);
+
+INSERT INTO users_tasks_completions (user_id, task_id, period_key, reward, completed_at)
+SELECT ut.user_id, ut.task_id, 'once', t.reward, COALESCE(ut.completed_at, NOW())
+FROM users_tasks ut
+JOIN tasks t USING (task_id)
+WHERE ut.complete;
@@ -0,0 +1,5 @@
+ALTER TABLE tasks
+    DROP CONSTRAINT tasks_window_check,
+    DROP COLUMN launched_at,
+    DROP COLUMN ends_at,
+    DROP COLUMN starts_at;
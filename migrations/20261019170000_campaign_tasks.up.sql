@@ -0,0 +1,5 @@
+ALTER TABLE tasks
+    ADD COLUMN starts_at TIMESTAMPTZ,
+    ADD COLUMN ends_at TIMESTAMPTZ,
+    ADD COLUMN launched_at TIMESTAMPTZ,
+    ADD CONSTRAINT tasks_window_check CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at);
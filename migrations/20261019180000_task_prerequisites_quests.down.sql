@@ -0,0 +1,10 @@
+DROP VIEW task_requirements;
+
+DROP TABLE users_quests;
+
+DROP INDEX idx_quest_steps_task_id;
+DROP TABLE quest_steps;
+
+DROP TABLE quests;
+
+DROP TABLE task_prerequisites;
@@ -0,0 +1,45 @@
+CREATE TABLE task_prerequisites (
+    task_id INT NOT NULL REFERENCES tasks (task_id) ON DELETE CASCADE,
+    prerequisite_id INT NOT NULL REFERENCES tasks (task_id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, prerequisite_id),
+    CHECK (task_id <> prerequisite_id)
+);
+
+CREATE TABLE quests (
+    quest_id SERIAL PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    bonus BIGINT NOT NULL DEFAULT 0 CHECK (bonus >= 0),
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE quest_steps (
+    quest_id INT NOT NULL REFERENCES quests (quest_id) ON DELETE CASCADE,
+    task_id INT NOT NULL REFERENCES tasks (task_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (quest_id, task_id),
+    UNIQUE (quest_id, position)
+);
+
+CREATE INDEX idx_quest_steps_task_id ON quest_steps (task_id);
+
+CREATE TABLE users_quests (
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    quest_id INT NOT NULL REFERENCES quests (quest_id) ON DELETE CASCADE,
+    bonus BIGINT NOT NULL,
+    completed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, quest_id)
+);
+
+-- every task a task requires: its own prerequisites and the earlier steps of active quests
+CREATE VIEW task_requirements AS
+SELECT task_id, prerequisite_id AS required_id
+FROM task_prerequisites
+UNION
+SELECT cur.task_id, prev.task_id
+FROM quest_steps cur
+JOIN quest_steps prev ON prev.quest_id = cur.quest_id AND prev.position < cur.position
+JOIN quests q ON q.quest_id = cur.quest_id
+WHERE q.active;
@@ -0,0 +1,5 @@
+DROP TRIGGER score_ledger_append_only ON score_ledger;
+DROP FUNCTION score_ledger_append_only();
+
+DROP INDEX idx_score_ledger_user_id;
+DROP TABLE score_ledger;
@@ -0,0 +1,33 @@
+CREATE TABLE score_ledger (
+    entry_id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL CHECK (amount <> 0),
+    balance BIGINT NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    task_id INT REFERENCES tasks (task_id),
+    quest_id INT REFERENCES quests (quest_id),
+    referral_user_id UUID,
+    period_key VARCHAR(32),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_score_ledger_user_id ON score_ledger (user_id, entry_id DESC);
+
+-- entries are never changed, they only go away together with their user
+CREATE FUNCTION score_ledger_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND NOT EXISTS (SELECT 1 FROM users WHERE user_id = OLD.user_id) THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'score_ledger is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER score_ledger_append_only
+BEFORE UPDATE OR DELETE ON score_ledger
+FOR EACH ROW EXECUTE FUNCTION score_ledger_append_only();
+
+INSERT INTO score_ledger (user_id, amount, balance, reason)
+SELECT user_id, score, score, 'opening_balance'
+FROM users_scoreboard
+WHERE score > 0;
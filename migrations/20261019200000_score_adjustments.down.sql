@@ -0,0 +1,3 @@
+ALTER TABLE score_ledger
+    DROP COLUMN comment,
+    DROP COLUMN actor_id;
@@ -0,0 +1,3 @@
+ALTER TABLE score_ledger
+    ADD COLUMN actor_id UUID,
+    ADD COLUMN comment TEXT NOT NULL DEFAULT '';
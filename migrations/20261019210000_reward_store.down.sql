@@ -0,0 +1,20 @@
+ALTER TABLE score_ledger
+    DROP CONSTRAINT score_ledger_amount_check,
+    DROP COLUMN redemption_id,
+    DROP COLUMN spendable_balance,
+    DROP COLUMN spendable_amount;
+
+-- redemptions and refunds do not change the score
+ALTER TABLE score_ledger DISABLE TRIGGER score_ledger_append_only;
+DELETE FROM score_ledger WHERE amount = 0;
+ALTER TABLE score_ledger ENABLE TRIGGER score_ledger_append_only;
+
+ALTER TABLE score_ledger ADD CONSTRAINT score_ledger_amount_check CHECK (amount <> 0);
+
+DROP INDEX idx_redemptions_status;
+DROP INDEX idx_redemptions_user_id;
+DROP TABLE redemptions;
+
+DROP TABLE reward_items;
+
+ALTER TABLE users_scoreboard DROP COLUMN spendable;
@@ -0,0 +1,35 @@
+ALTER TABLE users_scoreboard ADD COLUMN spendable BIGINT NOT NULL DEFAULT 0 CHECK (spendable >= 0);
+
+UPDATE users_scoreboard SET spendable = score;
+
+CREATE TABLE reward_items (
+    item_id SERIAL PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    cost BIGINT NOT NULL CHECK (cost > 0),
+    stock INT CHECK (stock >= 0),
+    per_user_limit INT CHECK (per_user_limit > 0),
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE redemptions (
+    redemption_id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    item_id INT NOT NULL REFERENCES reward_items (item_id),
+    cost BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'fulfilled', 'refunded')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_redemptions_user_id ON redemptions (user_id, item_id);
+CREATE INDEX idx_redemptions_status ON redemptions (status, redemption_id);
+
+ALTER TABLE score_ledger
+    DROP CONSTRAINT score_ledger_amount_check,
+    ADD COLUMN spendable_amount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN spendable_balance BIGINT,
+    ADD COLUMN redemption_id BIGINT REFERENCES redemptions (redemption_id),
+    ADD CONSTRAINT score_ledger_amount_check CHECK (amount <> 0 OR spendable_amount <> 0);
@@ -0,0 +1,9 @@
+-- transfers only move spendable points
+ALTER TABLE score_ledger DISABLE TRIGGER score_ledger_append_only;
+DELETE FROM score_ledger WHERE transfer_id IS NOT NULL;
+ALTER TABLE score_ledger ENABLE TRIGGER score_ledger_append_only;
+
+ALTER TABLE score_ledger DROP COLUMN transfer_id;
+
+DROP INDEX idx_transfers_sender_id;
+DROP TABLE transfers;
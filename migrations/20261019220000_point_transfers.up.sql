@@ -0,0 +1,14 @@
+-- the other side keeps its history when a user is deleted
+CREATE TABLE transfers (
+    transfer_id BIGSERIAL PRIMARY KEY,
+    sender_id UUID REFERENCES users (user_id) ON DELETE SET NULL,
+    recipient_id UUID REFERENCES users (user_id) ON DELETE SET NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (sender_id <> recipient_id)
+);
+
+CREATE INDEX idx_transfers_sender_id ON transfers (sender_id, created_at);
+
+ALTER TABLE score_ledger ADD COLUMN transfer_id BIGINT REFERENCES transfers (transfer_id);
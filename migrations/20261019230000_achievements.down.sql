@@ -0,0 +1,5 @@
+DROP INDEX idx_users_tasks_referrer_id;
+
+DROP TABLE users_achievements;
+
+DROP TABLE achievements;
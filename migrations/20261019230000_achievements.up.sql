@@ -0,0 +1,27 @@
+CREATE TABLE achievements (
+    achievement_id SERIAL PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    metric VARCHAR(32) NOT NULL CHECK (metric IN ('score', 'tasks_completed', 'referrals', 'streak')),
+    threshold BIGINT NOT NULL CHECK (threshold > 0),
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE users_achievements (
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    achievement_id INT NOT NULL REFERENCES achievements (achievement_id) ON DELETE CASCADE,
+    unlocked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, achievement_id)
+);
+
+-- referrals are counted by referrer
+CREATE INDEX idx_users_tasks_referrer_id ON users_tasks (referrer_id) WHERE referrer_id IS NOT NULL;
+
+INSERT INTO achievements (slug, title, description, metric, threshold) VALUES
+    ('first-task', 'first steps', 'complete your first task', 'tasks_completed', 1),
+    ('ten-tasks', 'getting things done', 'complete 10 tasks', 'tasks_completed', 10),
+    ('score-1000', 'high scorer', 'earn 1000 points', 'score', 1000),
+    ('first-referral', 'ambassador', 'invite your first friend', 'referrals', 1),
+    ('week-streak', 'on fire', 'complete tasks 7 days in a row', 'streak', 7);
@@ -0,0 +1,2 @@
+-- streak bonuses stay in the ledger and the score
+DROP TABLE users_streaks;
@@ -0,0 +1,26 @@
+-- last_day is the latest day in the user's timezone covered by a completion
+-- or a freeze
+CREATE TABLE users_streaks (
+    user_id UUID PRIMARY KEY REFERENCES users (user_id) ON DELETE CASCADE,
+    current INT NOT NULL DEFAULT 0,
+    longest INT NOT NULL DEFAULT 0,
+    last_day DATE,
+    freezes INT NOT NULL DEFAULT 0 CHECK (freezes >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO users_streaks (user_id, current, longest, last_day)
+SELECT user_id, (array_agg(days ORDER BY last_day DESC))[1], MAX(days), MAX(last_day)
+FROM (
+    SELECT user_id, COUNT(*) AS days, MAX(day) AS last_day
+    FROM (
+        SELECT user_id, day, day - (ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY day))::int AS run
+        FROM (
+            SELECT DISTINCT c.user_id, (c.completed_at AT TIME ZONE u.timezone)::date AS day
+            FROM users_tasks_completions c
+            JOIN users u USING (user_id)
+        ) d
+    ) r
+    GROUP BY user_id, run
+) runs
+GROUP BY user_id;
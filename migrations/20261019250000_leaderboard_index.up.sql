@@ -0,0 +1,2 @@
+-- leaderboard pages are read in (score DESC, user_id) order
+CREATE INDEX idx_users_scoreboard_rank ON users_scoreboard (score DESC, user_id);
@@ -0,0 +1 @@
+DROP INDEX idx_score_ledger_created_at;
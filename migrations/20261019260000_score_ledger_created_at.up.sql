@@ -0,0 +1,2 @@
+-- periodic leaderboards sum the ledger over a time range
+CREATE INDEX idx_score_ledger_created_at ON score_ledger (created_at) INCLUDE (user_id, amount) WHERE amount <> 0;
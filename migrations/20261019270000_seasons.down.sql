@@ -0,0 +1,16 @@
+DELETE FROM achievements WHERE metric = 'season_rank';
+
+ALTER TABLE achievements
+    DROP CONSTRAINT achievements_metric_check,
+    ADD CONSTRAINT achievements_metric_check CHECK (metric IN ('score', 'tasks_completed', 'referrals', 'streak'));
+
+-- season rewards stay in the ledger and the score
+ALTER TABLE score_ledger DROP COLUMN season_id;
+
+DROP INDEX idx_season_standings_user_id;
+DROP INDEX idx_season_standings_rank;
+DROP TABLE season_standings;
+
+DROP TABLE season_rewards;
+
+DROP TABLE seasons;
@@ -0,0 +1,38 @@
+CREATE TABLE seasons (
+    season_id SERIAL PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+-- a tier pays points to every rank down to up_to_rank not covered by a smaller tier
+CREATE TABLE season_rewards (
+    season_id INT NOT NULL REFERENCES seasons (season_id) ON DELETE CASCADE,
+    up_to_rank INT NOT NULL CHECK (up_to_rank > 0),
+    points BIGINT NOT NULL CHECK (points > 0),
+    PRIMARY KEY (season_id, up_to_rank)
+);
+
+-- final standings archived when a season closes
+CREATE TABLE season_standings (
+    season_id INT NOT NULL REFERENCES seasons (season_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    rank BIGINT NOT NULL,
+    score BIGINT NOT NULL,
+    reward BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (season_id, user_id)
+);
+
+CREATE INDEX idx_season_standings_rank ON season_standings (season_id, rank);
+CREATE INDEX idx_season_standings_user_id ON season_standings (user_id);
+
+ALTER TABLE score_ledger ADD COLUMN season_id INT REFERENCES seasons (season_id);
+
+-- season_rank achievements are awarded to the top of a closed season
+ALTER TABLE achievements
+    DROP CONSTRAINT achievements_metric_check,
+    ADD CONSTRAINT achievements_metric_check CHECK (metric IN ('score', 'tasks_completed', 'referrals', 'streak', 'season_rank'));
@@ -0,0 +1 @@
+DROP TABLE verification_callback_nonces;
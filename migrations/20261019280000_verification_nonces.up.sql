@@ -0,0 +1,7 @@
+-- nonces of applied verification callbacks, a signed callback is accepted only once
+CREATE TABLE verification_callback_nonces (
+    nonce VARCHAR(100) PRIMARY KEY,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_verification_callback_nonces_received_at ON verification_callback_nonces (received_at);
@@ -0,0 +1,8 @@
+-- number of users_scoreboard rows, kept in the registration transaction so
+-- that a rank lookup does not count the whole scoreboard
+CREATE TABLE users_scoreboard_size (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    total BIGINT NOT NULL CHECK (total >= 0)
+);
+
+INSERT INTO users_scoreboard_size (total) SELECT COUNT(*) FROM users_scoreboard;